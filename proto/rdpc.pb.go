@@ -350,17 +350,30 @@ func (x *Item) GetDesecrated() bool {
 }
 
 type Query struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-	Realm         string                 `protobuf:"bytes,3,opt,name=realm,proto3" json:"realm,omitempty"`
-	League        string                 `protobuf:"bytes,4,opt,name=league,proto3" json:"league,omitempty"`
-	Query         string                 `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
-	Update        uint32                 `protobuf:"varint,6,opt,name=update,proto3" json:"update,omitempty"`
-	NextRun       int64                  `protobuf:"varint,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
-	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	StartedAt     int64                  `protobuf:"varint,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	RunOnce       bool                   `protobuf:"varint,10,opt,name=run_once,json=runOnce,proto3" json:"run_once,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId         string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Realm          string                 `protobuf:"bytes,3,opt,name=realm,proto3" json:"realm,omitempty"`
+	League         string                 `protobuf:"bytes,4,opt,name=league,proto3" json:"league,omitempty"`
+	Query          string                 `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	Update         uint32                 `protobuf:"varint,6,opt,name=update,proto3" json:"update,omitempty"`
+	NextRun        int64                  `protobuf:"varint,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	StartedAt      int64                  `protobuf:"varint,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	RunOnce        bool                   `protobuf:"varint,10,opt,name=run_once,json=runOnce,proto3" json:"run_once,omitempty"`
+	LeaseOwner     string                 `protobuf:"bytes,11,opt,name=lease_owner,json=leaseOwner,proto3" json:"lease_owner,omitempty"`
+	LeaseExpiresAt int64                  `protobuf:"varint,12,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	LastError      string                 `protobuf:"bytes,13,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CompletedAt    int64                  `protobuf:"varint,14,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Attempts       uint32                 `protobuf:"varint,15,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Higher priorities are leased first.
+	Priority int32 `protobuf:"varint,16,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Minutes between runs or a cron expression evaluated in UTC. Takes
+	// precedence over update, which is in hours.
+	Schedule string `protobuf:"bytes,17,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// Id of the query this one duplicates, for rows that predate the unique
+	// (item_id, league, run_once) constraint. Zero for every other query.
+	DuplicateOf   uint64 `protobuf:"varint,18,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -465,6 +478,62 @@ func (x *Query) GetRunOnce() bool {
 	return false
 }
 
+func (x *Query) GetLeaseOwner() string {
+	if x != nil {
+		return x.LeaseOwner
+	}
+	return ""
+}
+
+func (x *Query) GetLeaseExpiresAt() int64 {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return 0
+}
+
+func (x *Query) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Query) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *Query) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Query) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Query) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Query) GetDuplicateOf() uint64 {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return 0
+}
+
 type Price struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
@@ -474,6 +543,7 @@ type Price struct {
 	Stock         int64                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
 	League        string                 `protobuf:"bytes,6,opt,name=league,proto3" json:"league,omitempty"`
 	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Id            uint64                 `protobuf:"varint,8,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -557,6 +627,13 @@ func (x *Price) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Price) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type BaseItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -854,8 +931,19 @@ func (x *BoolResponse) GetHas() bool {
 }
 
 type CategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Zero returns every matching row in a single response.
+	PageSize      uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Realm         string `protobuf:"bytes,4,opt,name=realm,proto3" json:"realm,omitempty"`
+	SubCategory   string `protobuf:"bytes,5,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Rarity        string `protobuf:"bytes,6,opt,name=rarity,proto3" json:"rarity,omitempty"`
+	NamePrefix    string `protobuf:"bytes,7,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	Corrupted     *bool  `protobuf:"varint,8,opt,name=corrupted,proto3,oneof" json:"corrupted,omitempty"`
+	Sanctified    *bool  `protobuf:"varint,9,opt,name=sanctified,proto3,oneof" json:"sanctified,omitempty"`
+	Duplicated    *bool  `protobuf:"varint,10,opt,name=duplicated,proto3,oneof" json:"duplicated,omitempty"`
+	Desecrated    *bool  `protobuf:"varint,11,opt,name=desecrated,proto3,oneof" json:"desecrated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -897,9 +985,83 @@ func (x *CategoryRequest) GetCategory() string {
 	return ""
 }
 
+func (x *CategoryRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *CategoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetRealm() string {
+	if x != nil {
+		return x.Realm
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetSubCategory() string {
+	if x != nil {
+		return x.SubCategory
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetRarity() string {
+	if x != nil {
+		return x.Rarity
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetCorrupted() bool {
+	if x != nil && x.Corrupted != nil {
+		return *x.Corrupted
+	}
+	return false
+}
+
+func (x *CategoryRequest) GetSanctified() bool {
+	if x != nil && x.Sanctified != nil {
+		return *x.Sanctified
+	}
+	return false
+}
+
+func (x *CategoryRequest) GetDuplicated() bool {
+	if x != nil && x.Duplicated != nil {
+		return *x.Duplicated
+	}
+	return false
+}
+
+func (x *CategoryRequest) GetDesecrated() bool {
+	if x != nil && x.Desecrated != nil {
+		return *x.Desecrated
+	}
+	return false
+}
+
 type Queries struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Queries       []*Query               `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Set by the lease RPCs when a realm's rate limit held queries back; the
+	// budget has room again after this many milliseconds.
+	RetryAfterMs  int64 `protobuf:"varint,3,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -941,9 +1103,24 @@ func (x *Queries) GetQueries() []*Query {
 	return nil
 }
 
+func (x *Queries) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *Queries) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
 type Items struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -985,9 +1162,17 @@ func (x *Items) GetItems() []*Item {
 	return nil
 }
 
+func (x *Items) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type BaseItems struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*BaseItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1029,6 +1214,13 @@ func (x *BaseItems) GetItems() []*BaseItem {
 	return nil
 }
 
+func (x *BaseItems) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type GetModRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -1117,122 +1309,2405 @@ func (x *GetModResponse) GetMod() string {
 	return ""
 }
 
-var File_proto_rdpc_proto protoreflect.FileDescriptor
+type PriceHistoryRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ItemId     string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	League     string                 `protobuf:"bytes,2,opt,name=league,proto3" json:"league,omitempty"`
+	CurrencyId string                 `protobuf:"bytes,3,opt,name=currency_id,json=currencyId,proto3" json:"currency_id,omitempty"`
+	From       int64                  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To         int64                  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	PageSize   uint32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken  string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Converts prices into this currency when set. Prices without a usable
+	// rate are omitted.
+	BaseCurrencyId string `protobuf:"bytes,8,opt,name=base_currency_id,json=baseCurrencyId,proto3" json:"base_currency_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_proto_rdpc_proto_rawDesc = "" +
-	"\n" +
-	"\x10proto/rdpc.proto\x12\x05proto\"?\n" +
-	"\x05Stats\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\"\xe3\x06\n" +
-	"\x04Item\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05realm\x18\x02 \x01(\tR\x05realm\x12\x1a\n" +
-	"\bcategory\x18\x03 \x01(\tR\bcategory\x12!\n" +
-	"\fsub_category\x18\x04 \x01(\tR\vsubCategory\x12\x12\n" +
-	"\x04icon\x18\x05 \x01(\tR\x04icon\x12$\n" +
-	"\x0eicon_tier_text\x18\x06 \x01(\tR\ficonTierText\x12\x12\n" +
-	"\x04name\x18\a \x01(\tR\x04name\x12\x1b\n" +
-	"\tbase_type\x18\b \x01(\tR\bbaseType\x12\x16\n" +
-	"\x06rarity\x18\t \x01(\tR\x06rarity\x12\f\n" +
-	"\x01w\x18\n" +
-	" \x01(\rR\x01w\x12\f\n" +
-	"\x01h\x18\v \x01(\rR\x01h\x12\x12\n" +
-	"\x04ilvl\x18\f \x01(\rR\x04ilvl\x12%\n" +
-	"\x0esocketed_items\x18\r \x01(\fR\rsocketedItems\x12\x1e\n" +
-	"\n" +
-	"properties\x18\x0e \x01(\fR\n" +
-	"properties\x12\"\n" +
-	"\frequirements\x18\x0f \x01(\fR\frequirements\x12!\n" +
-	"\fenchant_mods\x18\x10 \x01(\fR\venchantMods\x12\x1b\n" +
-	"\trune_mods\x18\x11 \x01(\fR\bruneMods\x12#\n" +
-	"\rimplicit_mods\x18\x12 \x01(\fR\fimplicitMods\x12#\n" +
-	"\rexplicit_mods\x18\x13 \x01(\fR\fexplicitMods\x12%\n" +
-	"\x0efractured_mods\x18\x14 \x01(\fR\rfracturedMods\x12'\n" +
-	"\x0fdesecrated_mods\x18\x15 \x01(\fR\x0edesecratedMods\x12!\n" +
-	"\fflavour_text\x18\x16 \x01(\tR\vflavourText\x12\x1d\n" +
-	"\n" +
-	"descr_text\x18\x17 \x01(\tR\tdescrText\x12$\n" +
-	"\x0esec_descr_text\x18\x18 \x01(\tR\fsecDescrText\x12\x18\n" +
-	"\asupport\x18\x19 \x01(\bR\asupport\x12\x1e\n" +
-	"\n" +
-	"duplicated\x18\x1a \x01(\bR\n" +
-	"duplicated\x12\x1c\n" +
-	"\tcorrupted\x18\x1b \x01(\bR\tcorrupted\x12\x1e\n" +
-	"\n" +
-	"sanctified\x18\x1c \x01(\bR\n" +
-	"sanctified\x12\x1e\n" +
-	"\n" +
-	"desecrated\x18\x1d \x01(\bR\n" +
-	"desecrated\"\xf9\x01\n" +
-	"\x05Query\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
-	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x14\n" +
-	"\x05realm\x18\x03 \x01(\tR\x05realm\x12\x16\n" +
-	"\x06league\x18\x04 \x01(\tR\x06league\x12\x14\n" +
-	"\x05query\x18\x05 \x01(\tR\x05query\x12\x16\n" +
-	"\x06update\x18\x06 \x01(\rR\x06update\x12\x19\n" +
-	"\bnext_run\x18\a \x01(\x03R\anextRun\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"started_at\x18\t \x01(\x03R\tstartedAt\x12\x19\n" +
-	"\brun_once\x18\n" +
-	" \x01(\bR\arunOnce\"\xbb\x01\n" +
-	"\x05Price\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x14\n" +
-	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1f\n" +
-	"\vcurrency_id\x18\x03 \x01(\tR\n" +
-	"currencyId\x12\x16\n" +
-	"\x06volume\x18\x04 \x01(\x03R\x06volume\x12\x14\n" +
-	"\x05stock\x18\x05 \x01(\x03R\x05stock\x12\x16\n" +
-	"\x06league\x18\x06 \x01(\tR\x06league\x12\x1c\n" +
-	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\"a\n" +
-	"\bBaseItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05realm\x18\x02 \x01(\tR\x05realm\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
-	"\tbase_type\x18\x04 \x01(\tR\bbaseType\"A\n" +
-	"\x0eHasItemRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
-	"\tbase_type\x18\x02 \x01(\tR\bbaseType\"(\n" +
-	"\rItemIDRequest\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"B\n" +
-	"\x0fHasPriceRequest\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
-	"\x06league\x18\x02 \x01(\tR\x06league\"\a\n" +
-	"\x05Empty\" \n" +
-	"\fBoolResponse\x12\x10\n" +
-	"\x03has\x18\x01 \x01(\bR\x03has\"-\n" +
-	"\x0fCategoryRequest\x12\x1a\n" +
-	"\bcategory\x18\x01 \x01(\tR\bcategory\"1\n" +
-	"\aQueries\x12&\n" +
-	"\aqueries\x18\x01 \x03(\v2\f.proto.QueryR\aqueries\"*\n" +
-	"\x05Items\x12!\n" +
-	"\x05items\x18\x01 \x03(\v2\v.proto.ItemR\x05items\"2\n" +
-	"\tBaseItems\x12%\n" +
-	"\x05items\x18\x01 \x03(\v2\x0f.proto.BaseItemR\x05items\"#\n" +
-	"\rGetModRequest\x12\x12\n" +
-	"\x04hash\x18\x01 \x01(\tR\x04hash\"\"\n" +
-	"\x0eGetModResponse\x12\x10\n" +
-	"\x03mod\x18\x01 \x01(\tR\x03mod2\xc9\x06\n" +
-	"\bDatabase\x12+\n" +
-	"\vInsertStats\x12\f.proto.Stats\x1a\f.proto.Empty\"\x00\x12)\n" +
-	"\n" +
-	"InsertItem\x12\v.proto.Item\x1a\f.proto.Empty\"\x00\x12/\n" +
-	"\x10InsertItemWithID\x12\v.proto.Item\x1a\f.proto.Empty\"\x00\x12+\n" +
-	"\vInsertQuery\x12\f.proto.Query\x1a\f.proto.Empty\"\x00\x12+\n" +
-	"\vInsertPrice\x12\f.proto.Price\x1a\f.proto.Empty\"\x00\x127\n" +
-	"\aHasItem\x12\x15.proto.HasItemRequest\x1a\x13.proto.BoolResponse\"\x00\x126\n" +
-	"\aHasInfo\x12\x14.proto.ItemIDRequest\x1a\x13.proto.BoolResponse\"\x00\x12>\n" +
-	"\rHasPriceQuery\x12\x16.proto.HasPriceRequest\x1a\x13.proto.BoolResponse\"\x00\x12:\n" +
-	"\fGetBaseItems\x12\x16.proto.CategoryRequest\x1a\x10.proto.BaseItems\"\x00\x120\n" +
-	"\x0eGetInfoQueries\x12\f.proto.Empty\x1a\x0e.proto.Queries\"\x00\x121\n" +
-	"\x0fGetPriceQueries\x12\f.proto.Empty\x1a\x0e.proto.Queries\"\x00\x127\n" +
-	"\x06GetMod\x12\x14.proto.GetModRequest\x1a\x15.proto.GetModResponse\"\x00\x12<\n" +
-	"\x12GetItemsByCategory\x12\x16.proto.CategoryRequest\x1a\f.proto.Items\"\x00\x12-\n" +
-	"\x0eUpdateItemInfo\x12\v.proto.Item\x1a\f.proto.Empty\"\x00\x12-\n" +
-	"\rUpdateNextRun\x12\f.proto.Query\x1a\f.proto.Empty\"\x00\x123\n" +
+func (x *PriceHistoryRequest) Reset() {
+	*x = PriceHistoryRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistoryRequest) ProtoMessage() {}
+
+func (x *PriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*PriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PriceHistoryRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *PriceHistoryRequest) GetLeague() string {
+	if x != nil {
+		return x.League
+	}
+	return ""
+}
+
+func (x *PriceHistoryRequest) GetCurrencyId() string {
+	if x != nil {
+		return x.CurrencyId
+	}
+	return ""
+}
+
+func (x *PriceHistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PriceHistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *PriceHistoryRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PriceHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *PriceHistoryRequest) GetBaseCurrencyId() string {
+	if x != nil {
+		return x.BaseCurrencyId
+	}
+	return ""
+}
+
+type PriceHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*Price               `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistory) Reset() {
+	*x = PriceHistory{}
+	mi := &file_proto_rdpc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistory) ProtoMessage() {}
+
+func (x *PriceHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistory.ProtoReflect.Descriptor instead.
+func (*PriceHistory) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PriceHistory) GetPrices() []*Price {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *PriceHistory) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PriceCandlesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ItemId     string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	League     string                 `protobuf:"bytes,2,opt,name=league,proto3" json:"league,omitempty"`
+	CurrencyId string                 `protobuf:"bytes,3,opt,name=currency_id,json=currencyId,proto3" json:"currency_id,omitempty"`
+	Interval   string                 `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	From       int64                  `protobuf:"varint,5,opt,name=from,proto3" json:"from,omitempty"`
+	To         int64                  `protobuf:"varint,6,opt,name=to,proto3" json:"to,omitempty"`
+	// Converts prices into this currency when set, in which case currency_id
+	// is optional. Prices without a usable rate are omitted.
+	BaseCurrencyId string `protobuf:"bytes,7,opt,name=base_currency_id,json=baseCurrencyId,proto3" json:"base_currency_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PriceCandlesRequest) Reset() {
+	*x = PriceCandlesRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceCandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceCandlesRequest) ProtoMessage() {}
+
+func (x *PriceCandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceCandlesRequest.ProtoReflect.Descriptor instead.
+func (*PriceCandlesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PriceCandlesRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *PriceCandlesRequest) GetLeague() string {
+	if x != nil {
+		return x.League
+	}
+	return ""
+}
+
+func (x *PriceCandlesRequest) GetCurrencyId() string {
+	if x != nil {
+		return x.CurrencyId
+	}
+	return ""
+}
+
+func (x *PriceCandlesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *PriceCandlesRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PriceCandlesRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *PriceCandlesRequest) GetBaseCurrencyId() string {
+	if x != nil {
+		return x.BaseCurrencyId
+	}
+	return ""
+}
+
+type PriceCandle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int64                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Vwap          float64                `protobuf:"fixed64,6,opt,name=vwap,proto3" json:"vwap,omitempty"`
+	Volume        int64                  `protobuf:"varint,7,opt,name=volume,proto3" json:"volume,omitempty"`
+	Stock         int64                  `protobuf:"varint,8,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceCandle) Reset() {
+	*x = PriceCandle{}
+	mi := &file_proto_rdpc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceCandle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceCandle) ProtoMessage() {}
+
+func (x *PriceCandle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceCandle.ProtoReflect.Descriptor instead.
+func (*PriceCandle) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PriceCandle) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetVwap() float64 {
+	if x != nil {
+		return x.Vwap
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *PriceCandle) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+type PriceCandles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candles       []*PriceCandle         `protobuf:"bytes,1,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceCandles) Reset() {
+	*x = PriceCandles{}
+	mi := &file_proto_rdpc_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceCandles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceCandles) ProtoMessage() {}
+
+func (x *PriceCandles) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceCandles.ProtoReflect.Descriptor instead.
+func (*PriceCandles) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PriceCandles) GetCandles() []*PriceCandle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+type LatestPricesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	League   string                 `protobuf:"bytes,2,opt,name=league,proto3" json:"league,omitempty"`
+	// Converts prices into this currency when set. Prices without a usable
+	// rate are omitted.
+	BaseCurrencyId string `protobuf:"bytes,3,opt,name=base_currency_id,json=baseCurrencyId,proto3" json:"base_currency_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LatestPricesRequest) Reset() {
+	*x = LatestPricesRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatestPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatestPricesRequest) ProtoMessage() {}
+
+func (x *LatestPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatestPricesRequest.ProtoReflect.Descriptor instead.
+func (*LatestPricesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LatestPricesRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *LatestPricesRequest) GetLeague() string {
+	if x != nil {
+		return x.League
+	}
+	return ""
+}
+
+func (x *LatestPricesRequest) GetBaseCurrencyId() string {
+	if x != nil {
+		return x.BaseCurrencyId
+	}
+	return ""
+}
+
+type LatestPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *BaseItem              `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	CurrencyId    string                 `protobuf:"bytes,3,opt,name=currency_id,json=currencyId,proto3" json:"currency_id,omitempty"`
+	Volume        int64                  `protobuf:"varint,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	Stock         int64                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatestPrice) Reset() {
+	*x = LatestPrice{}
+	mi := &file_proto_rdpc_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatestPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatestPrice) ProtoMessage() {}
+
+func (x *LatestPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatestPrice.ProtoReflect.Descriptor instead.
+func (*LatestPrice) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LatestPrice) GetItem() *BaseItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *LatestPrice) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *LatestPrice) GetCurrencyId() string {
+	if x != nil {
+		return x.CurrencyId
+	}
+	return ""
+}
+
+func (x *LatestPrice) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *LatestPrice) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *LatestPrice) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type LatestPrices struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*LatestPrice         `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatestPrices) Reset() {
+	*x = LatestPrices{}
+	mi := &file_proto_rdpc_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatestPrices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatestPrices) ProtoMessage() {}
+
+func (x *LatestPrices) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatestPrices.ProtoReflect.Descriptor instead.
+func (*LatestPrices) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LatestPrices) GetPrices() []*LatestPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+// One unit of currency_id is worth rate units of base_currency_id.
+type CurrencyRate struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	League         string                 `protobuf:"bytes,1,opt,name=league,proto3" json:"league,omitempty"`
+	CurrencyId     string                 `protobuf:"bytes,2,opt,name=currency_id,json=currencyId,proto3" json:"currency_id,omitempty"`
+	BaseCurrencyId string                 `protobuf:"bytes,3,opt,name=base_currency_id,json=baseCurrencyId,proto3" json:"base_currency_id,omitempty"`
+	Rate           float64                `protobuf:"fixed64,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	Timestamp      int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CurrencyRate) Reset() {
+	*x = CurrencyRate{}
+	mi := &file_proto_rdpc_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyRate) ProtoMessage() {}
+
+func (x *CurrencyRate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyRate.ProtoReflect.Descriptor instead.
+func (*CurrencyRate) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CurrencyRate) GetLeague() string {
+	if x != nil {
+		return x.League
+	}
+	return ""
+}
+
+func (x *CurrencyRate) GetCurrencyId() string {
+	if x != nil {
+		return x.CurrencyId
+	}
+	return ""
+}
+
+func (x *CurrencyRate) GetBaseCurrencyId() string {
+	if x != nil {
+		return x.BaseCurrencyId
+	}
+	return ""
+}
+
+func (x *CurrencyRate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *CurrencyRate) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type Prices struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*Price               `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Prices) Reset() {
+	*x = Prices{}
+	mi := &file_proto_rdpc_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prices) ProtoMessage() {}
+
+func (x *Prices) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prices.ProtoReflect.Descriptor instead.
+func (*Prices) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Prices) GetPrices() []*Price {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type StatsBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*Stats               `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsBatch) Reset() {
+	*x = StatsBatch{}
+	mi := &file_proto_rdpc_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsBatch) ProtoMessage() {}
+
+func (x *StatsBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsBatch.ProtoReflect.Descriptor instead.
+func (*StatsBatch) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StatsBatch) GetStats() []*Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type RowResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RowResult) Reset() {
+	*x = RowResult{}
+	mi := &file_proto_rdpc_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowResult) ProtoMessage() {}
+
+func (x *RowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowResult.ProtoReflect.Descriptor instead.
+func (*RowResult) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RowResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RowResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *RowResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*RowResult           `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Inserted      uint32                 `protobuf:"varint,2,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Failed        uint32                 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_proto_rdpc_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BatchResult) GetResults() []*RowResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchResult) GetInserted() uint32 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *BatchResult) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type StreamSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      uint64                 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      uint64                 `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	FirstError    string                 `protobuf:"bytes,3,opt,name=first_error,json=firstError,proto3" json:"first_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamSummary) Reset() {
+	*x = StreamSummary{}
+	mi := &file_proto_rdpc_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSummary) ProtoMessage() {}
+
+func (x *StreamSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSummary.ProtoReflect.Descriptor instead.
+func (*StreamSummary) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StreamSummary) GetAccepted() uint64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetRejected() uint64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetFirstError() string {
+	if x != nil {
+		return x.FirstError
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ItemHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *BaseItem              `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Rank          float64                `protobuf:"fixed64,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemHit) Reset() {
+	*x = ItemHit{}
+	mi := &file_proto_rdpc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemHit) ProtoMessage() {}
+
+func (x *ItemHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemHit.ProtoReflect.Descriptor instead.
+func (*ItemHit) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ItemHit) GetItem() *BaseItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *ItemHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *ItemHit) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type ModHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stat          *Stats                 `protobuf:"bytes,1,opt,name=stat,proto3" json:"stat,omitempty"`
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Rank          float64                `protobuf:"fixed64,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModHit) Reset() {
+	*x = ModHit{}
+	mi := &file_proto_rdpc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModHit) ProtoMessage() {}
+
+func (x *ModHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModHit.ProtoReflect.Descriptor instead.
+func (*ModHit) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ModHit) GetStat() *Stats {
+	if x != nil {
+		return x.Stat
+	}
+	return nil
+}
+
+func (x *ModHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *ModHit) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type SearchResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ItemHit             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Mods          []*ModHit              `protobuf:"bytes,2,rep,name=mods,proto3" json:"mods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResults) Reset() {
+	*x = SearchResults{}
+	mi := &file_proto_rdpc_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResults) ProtoMessage() {}
+
+func (x *SearchResults) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResults.ProtoReflect.Descriptor instead.
+func (*SearchResults) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SearchResults) GetItems() []*ItemHit {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *SearchResults) GetMods() []*ModHit {
+	if x != nil {
+		return x.Mods
+	}
+	return nil
+}
+
+// Zero values fall back to the server defaults, so an empty request behaves
+// like the old Empty one.
+type LeaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	BatchSize     uint32                 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	LeaseSeconds  uint32                 `protobuf:"varint,3,opt,name=lease_seconds,json=leaseSeconds,proto3" json:"lease_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRequest) Reset() {
+	*x = LeaseRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest) ProtoMessage() {}
+
+func (x *LeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LeaseRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *LeaseRequest) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *LeaseRequest) GetLeaseSeconds() uint32 {
+	if x != nil {
+		return x.LeaseSeconds
+	}
+	return 0
+}
+
+type ExtendLeaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	LeaseSeconds  uint32                 `protobuf:"varint,3,opt,name=lease_seconds,json=leaseSeconds,proto3" json:"lease_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendLeaseRequest) Reset() {
+	*x = ExtendLeaseRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendLeaseRequest) ProtoMessage() {}
+
+func (x *ExtendLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendLeaseRequest.ProtoReflect.Descriptor instead.
+func (*ExtendLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExtendLeaseRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ExtendLeaseRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *ExtendLeaseRequest) GetLeaseSeconds() uint32 {
+	if x != nil {
+		return x.LeaseSeconds
+	}
+	return 0
+}
+
+type QueryLeaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLeaseRequest) Reset() {
+	*x = QueryLeaseRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLeaseRequest) ProtoMessage() {}
+
+func (x *QueryLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLeaseRequest.ProtoReflect.Descriptor instead.
+func (*QueryLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *QueryLeaseRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *QueryLeaseRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+type FailQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailQueryRequest) Reset() {
+	*x = FailQueryRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailQueryRequest) ProtoMessage() {}
+
+func (x *FailQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailQueryRequest.ProtoReflect.Descriptor instead.
+func (*FailQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *FailQueryRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FailQueryRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *FailQueryRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      uint32                 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PageRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type QueryIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryIDRequest) Reset() {
+	*x = QueryIDRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryIDRequest) ProtoMessage() {}
+
+func (x *QueryIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryIDRequest.ProtoReflect.Descriptor instead.
+func (*QueryIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *QueryIDRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchQueriesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	// Maximum number of unexpired leases the worker holds at once.
+	Capacity      uint32 `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	LeaseSeconds  uint32 `protobuf:"varint,3,opt,name=lease_seconds,json=leaseSeconds,proto3" json:"lease_seconds,omitempty"`
+	RunOnce       bool   `protobuf:"varint,4,opt,name=run_once,json=runOnce,proto3" json:"run_once,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchQueriesRequest) Reset() {
+	*x = WatchQueriesRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchQueriesRequest) ProtoMessage() {}
+
+func (x *WatchQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchQueriesRequest.ProtoReflect.Descriptor instead.
+func (*WatchQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *WatchQueriesRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *WatchQueriesRequest) GetCapacity() uint32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *WatchQueriesRequest) GetLeaseSeconds() uint32 {
+	if x != nil {
+		return x.LeaseSeconds
+	}
+	return 0
+}
+
+func (x *WatchQueriesRequest) GetRunOnce() bool {
+	if x != nil {
+		return x.RunOnce
+	}
+	return false
+}
+
+// The insert results carry the stored row. created is false when an upsert
+// updated an existing row instead.
+type QueryResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Query         *Query                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResult) Reset() {
+	*x = QueryResult{}
+	mi := &file_proto_rdpc_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResult) ProtoMessage() {}
+
+func (x *QueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResult.ProtoReflect.Descriptor instead.
+func (*QueryResult) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *QueryResult) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *QueryResult) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *QueryResult) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type ItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Item          *Item                  `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemResult) Reset() {
+	*x = ItemResult{}
+	mi := &file_proto_rdpc_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemResult) ProtoMessage() {}
+
+func (x *ItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemResult.ProtoReflect.Descriptor instead.
+func (*ItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ItemResult) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *ItemResult) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type PriceResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Price         *Price                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceResult) Reset() {
+	*x = PriceResult{}
+	mi := &file_proto_rdpc_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceResult) ProtoMessage() {}
+
+func (x *PriceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceResult.ProtoReflect.Descriptor instead.
+func (*PriceResult) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PriceResult) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PriceResult) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *PriceResult) GetPrice() *Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type ListQueriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	League        string                 `protobuf:"bytes,2,opt,name=league,proto3" json:"league,omitempty"`
+	Realm         string                 `protobuf:"bytes,3,opt,name=realm,proto3" json:"realm,omitempty"`
+	RunOnce       *bool                  `protobuf:"varint,4,opt,name=run_once,json=runOnce,proto3,oneof" json:"run_once,omitempty"`
+	ItemId        string                 `protobuf:"bytes,5,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	PageSize      uint32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueriesRequest) Reset() {
+	*x = ListQueriesRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueriesRequest) ProtoMessage() {}
+
+func (x *ListQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ListQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListQueriesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListQueriesRequest) GetLeague() string {
+	if x != nil {
+		return x.League
+	}
+	return ""
+}
+
+func (x *ListQueriesRequest) GetRealm() string {
+	if x != nil {
+		return x.Realm
+	}
+	return ""
+}
+
+func (x *ListQueriesRequest) GetRunOnce() bool {
+	if x != nil && x.RunOnce != nil {
+		return *x.RunOnce
+	}
+	return false
+}
+
+func (x *ListQueriesRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListQueriesRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListQueriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Caller        string                 `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Code          string                 `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_proto_rdpc_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AuditEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Caller        string                 `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	From          int64                  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	PageSize      uint32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_rdpc_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListAuditEventsRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AuditEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvents) Reset() {
+	*x = AuditEvents{}
+	mi := &file_proto_rdpc_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvents) ProtoMessage() {}
+
+func (x *AuditEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rdpc_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvents.ProtoReflect.Descriptor instead.
+func (*AuditEvents) Descriptor() ([]byte, []int) {
+	return file_proto_rdpc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AuditEvents) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *AuditEvents) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_proto_rdpc_proto protoreflect.FileDescriptor
+
+const file_proto_rdpc_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/rdpc.proto\x12\x05proto\"?\n" +
+	"\x05Stats\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"\xe3\x06\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05realm\x18\x02 \x01(\tR\x05realm\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12!\n" +
+	"\fsub_category\x18\x04 \x01(\tR\vsubCategory\x12\x12\n" +
+	"\x04icon\x18\x05 \x01(\tR\x04icon\x12$\n" +
+	"\x0eicon_tier_text\x18\x06 \x01(\tR\ficonTierText\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x12\x1b\n" +
+	"\tbase_type\x18\b \x01(\tR\bbaseType\x12\x16\n" +
+	"\x06rarity\x18\t \x01(\tR\x06rarity\x12\f\n" +
+	"\x01w\x18\n" +
+	" \x01(\rR\x01w\x12\f\n" +
+	"\x01h\x18\v \x01(\rR\x01h\x12\x12\n" +
+	"\x04ilvl\x18\f \x01(\rR\x04ilvl\x12%\n" +
+	"\x0esocketed_items\x18\r \x01(\fR\rsocketedItems\x12\x1e\n" +
+	"\n" +
+	"properties\x18\x0e \x01(\fR\n" +
+	"properties\x12\"\n" +
+	"\frequirements\x18\x0f \x01(\fR\frequirements\x12!\n" +
+	"\fenchant_mods\x18\x10 \x01(\fR\venchantMods\x12\x1b\n" +
+	"\trune_mods\x18\x11 \x01(\fR\bruneMods\x12#\n" +
+	"\rimplicit_mods\x18\x12 \x01(\fR\fimplicitMods\x12#\n" +
+	"\rexplicit_mods\x18\x13 \x01(\fR\fexplicitMods\x12%\n" +
+	"\x0efractured_mods\x18\x14 \x01(\fR\rfracturedMods\x12'\n" +
+	"\x0fdesecrated_mods\x18\x15 \x01(\fR\x0edesecratedMods\x12!\n" +
+	"\fflavour_text\x18\x16 \x01(\tR\vflavourText\x12\x1d\n" +
+	"\n" +
+	"descr_text\x18\x17 \x01(\tR\tdescrText\x12$\n" +
+	"\x0esec_descr_text\x18\x18 \x01(\tR\fsecDescrText\x12\x18\n" +
+	"\asupport\x18\x19 \x01(\bR\asupport\x12\x1e\n" +
+	"\n" +
+	"duplicated\x18\x1a \x01(\bR\n" +
+	"duplicated\x12\x1c\n" +
+	"\tcorrupted\x18\x1b \x01(\bR\tcorrupted\x12\x1e\n" +
+	"\n" +
+	"sanctified\x18\x1c \x01(\bR\n" +
+	"sanctified\x12\x1e\n" +
+	"\n" +
+	"desecrated\x18\x1d \x01(\bR\n" +
+	"desecrated\"\xfd\x03\n" +
+	"\x05Query\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05realm\x18\x03 \x01(\tR\x05realm\x12\x16\n" +
+	"\x06league\x18\x04 \x01(\tR\x06league\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12\x16\n" +
+	"\x06update\x18\x06 \x01(\rR\x06update\x12\x19\n" +
+	"\bnext_run\x18\a \x01(\x03R\anextRun\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\x03R\tstartedAt\x12\x19\n" +
+	"\brun_once\x18\n" +
+	" \x01(\bR\arunOnce\x12\x1f\n" +
+	"\vlease_owner\x18\v \x01(\tR\n" +
+	"leaseOwner\x12(\n" +
+	"\x10lease_expires_at\x18\f \x01(\x03R\x0eleaseExpiresAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\r \x01(\tR\tlastError\x12!\n" +
+	"\fcompleted_at\x18\x0e \x01(\x03R\vcompletedAt\x12\x1a\n" +
+	"\battempts\x18\x0f \x01(\rR\battempts\x12\x1a\n" +
+	"\bpriority\x18\x10 \x01(\x05R\bpriority\x12\x1a\n" +
+	"\bschedule\x18\x11 \x01(\tR\bschedule\x12!\n" +
+	"\fduplicate_of\x18\x12 \x01(\x04R\vduplicateOf\"\xcb\x01\n" +
+	"\x05Price\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1f\n" +
+	"\vcurrency_id\x18\x03 \x01(\tR\n" +
+	"currencyId\x12\x16\n" +
+	"\x06volume\x18\x04 \x01(\x03R\x06volume\x12\x14\n" +
+	"\x05stock\x18\x05 \x01(\x03R\x05stock\x12\x16\n" +
+	"\x06league\x18\x06 \x01(\tR\x06league\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x0e\n" +
+	"\x02id\x18\b \x01(\x04R\x02id\"a\n" +
+	"\bBaseItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05realm\x18\x02 \x01(\tR\x05realm\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tbase_type\x18\x04 \x01(\tR\bbaseType\"A\n" +
+	"\x0eHasItemRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tbase_type\x18\x02 \x01(\tR\bbaseType\"(\n" +
+	"\rItemIDRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"B\n" +
+	"\x0fHasPriceRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06league\x18\x02 \x01(\tR\x06league\"\a\n" +
+	"\x05Empty\" \n" +
+	"\fBoolResponse\x12\x10\n" +
+	"\x03has\x18\x01 \x01(\bR\x03has\"\xa8\x03\n" +
+	"\x0fCategoryRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x14\n" +
+	"\x05realm\x18\x04 \x01(\tR\x05realm\x12!\n" +
+	"\fsub_category\x18\x05 \x01(\tR\vsubCategory\x12\x16\n" +
+	"\x06rarity\x18\x06 \x01(\tR\x06rarity\x12\x1f\n" +
+	"\vname_prefix\x18\a \x01(\tR\n" +
+	"namePrefix\x12!\n" +
+	"\tcorrupted\x18\b \x01(\bH\x00R\tcorrupted\x88\x01\x01\x12#\n" +
+	"\n" +
+	"sanctified\x18\t \x01(\bH\x01R\n" +
+	"sanctified\x88\x01\x01\x12#\n" +
+	"\n" +
+	"duplicated\x18\n" +
+	" \x01(\bH\x02R\n" +
+	"duplicated\x88\x01\x01\x12#\n" +
+	"\n" +
+	"desecrated\x18\v \x01(\bH\x03R\n" +
+	"desecrated\x88\x01\x01B\f\n" +
+	"\n" +
+	"_corruptedB\r\n" +
+	"\v_sanctifiedB\r\n" +
+	"\v_duplicatedB\r\n" +
+	"\v_desecrated\"\x7f\n" +
+	"\aQueries\x12&\n" +
+	"\aqueries\x18\x01 \x03(\v2\f.proto.QueryR\aqueries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12$\n" +
+	"\x0eretry_after_ms\x18\x03 \x01(\x03R\fretryAfterMs\"R\n" +
+	"\x05Items\x12!\n" +
+	"\x05items\x18\x01 \x03(\v2\v.proto.ItemR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"Z\n" +
+	"\tBaseItems\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.proto.BaseItemR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"#\n" +
+	"\rGetModRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"\"\n" +
+	"\x0eGetModResponse\x12\x10\n" +
+	"\x03mod\x18\x01 \x01(\tR\x03mod\"\xf1\x01\n" +
+	"\x13PriceHistoryRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06league\x18\x02 \x01(\tR\x06league\x12\x1f\n" +
+	"\vcurrency_id\x18\x03 \x01(\tR\n" +
+	"currencyId\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\x12(\n" +
+	"\x10base_currency_id\x18\b \x01(\tR\x0ebaseCurrencyId\"\\\n" +
+	"\fPriceHistory\x12$\n" +
+	"\x06prices\x18\x01 \x03(\v2\f.proto.PriceR\x06prices\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd1\x01\n" +
+	"\x13PriceCandlesRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06league\x18\x02 \x01(\tR\x06league\x12\x1f\n" +
+	"\vcurrency_id\x18\x03 \x01(\tR\n" +
+	"currencyId\x12\x1a\n" +
+	"\binterval\x18\x04 \x01(\tR\binterval\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\x03R\x02to\x12(\n" +
+	"\x10base_currency_id\x18\a \x01(\tR\x0ebaseCurrencyId\"\xb5\x01\n" +
+	"\vPriceCandle\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x03R\x05start\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x01R\x05close\x12\x12\n" +
+	"\x04vwap\x18\x06 \x01(\x01R\x04vwap\x12\x16\n" +
+	"\x06volume\x18\a \x01(\x03R\x06volume\x12\x14\n" +
+	"\x05stock\x18\b \x01(\x03R\x05stock\"<\n" +
+	"\fPriceCandles\x12,\n" +
+	"\acandles\x18\x01 \x03(\v2\x12.proto.PriceCandleR\acandles\"s\n" +
+	"\x13LatestPricesRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06league\x18\x02 \x01(\tR\x06league\x12(\n" +
+	"\x10base_currency_id\x18\x03 \x01(\tR\x0ebaseCurrencyId\"\xb5\x01\n" +
+	"\vLatestPrice\x12#\n" +
+	"\x04item\x18\x01 \x01(\v2\x0f.proto.BaseItemR\x04item\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1f\n" +
+	"\vcurrency_id\x18\x03 \x01(\tR\n" +
+	"currencyId\x12\x16\n" +
+	"\x06volume\x18\x04 \x01(\x03R\x06volume\x12\x14\n" +
+	"\x05stock\x18\x05 \x01(\x03R\x05stock\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\":\n" +
+	"\fLatestPrices\x12*\n" +
+	"\x06prices\x18\x01 \x03(\v2\x12.proto.LatestPriceR\x06prices\"\xa3\x01\n" +
+	"\fCurrencyRate\x12\x16\n" +
+	"\x06league\x18\x01 \x01(\tR\x06league\x12\x1f\n" +
+	"\vcurrency_id\x18\x02 \x01(\tR\n" +
+	"currencyId\x12(\n" +
+	"\x10base_currency_id\x18\x03 \x01(\tR\x0ebaseCurrencyId\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\x01R\x04rate\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\".\n" +
+	"\x06Prices\x12$\n" +
+	"\x06prices\x18\x01 \x03(\v2\f.proto.PriceR\x06prices\"0\n" +
+	"\n" +
+	"StatsBatch\x12\"\n" +
+	"\x05stats\x18\x01 \x03(\v2\f.proto.StatsR\x05stats\"G\n" +
+	"\tRowResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"m\n" +
+	"\vBatchResult\x12*\n" +
+	"\aresults\x18\x01 \x03(\v2\x10.proto.RowResultR\aresults\x12\x1a\n" +
+	"\binserted\x18\x02 \x01(\rR\binserted\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\rR\x06failed\"h\n" +
+	"\rStreamSummary\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x04R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x04R\brejected\x12\x1f\n" +
+	"\vfirst_error\x18\x03 \x01(\tR\n" +
+	"firstError\"W\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\"\\\n" +
+	"\aItemHit\x12#\n" +
+	"\x04item\x18\x01 \x01(\v2\x0f.proto.BaseItemR\x04item\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x01R\x04rank\"X\n" +
+	"\x06ModHit\x12 \n" +
+	"\x04stat\x18\x01 \x01(\v2\f.proto.StatsR\x04stat\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x01R\x04rank\"X\n" +
+	"\rSearchResults\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.proto.ItemHitR\x05items\x12!\n" +
+	"\x04mods\x18\x02 \x03(\v2\r.proto.ModHitR\x04mods\"o\n" +
+	"\fLeaseRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\rR\tbatchSize\x12#\n" +
+	"\rlease_seconds\x18\x03 \x01(\rR\fleaseSeconds\"f\n" +
+	"\x12ExtendLeaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x12#\n" +
+	"\rlease_seconds\x18\x03 \x01(\rR\fleaseSeconds\"@\n" +
+	"\x11QueryLeaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\"U\n" +
+	"\x10FailQueryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"I\n" +
+	"\vPageRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\" \n" +
+	"\x0eQueryIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x8e\x01\n" +
+	"\x13WatchQueriesRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12\x1a\n" +
+	"\bcapacity\x18\x02 \x01(\rR\bcapacity\x12#\n" +
+	"\rlease_seconds\x18\x03 \x01(\rR\fleaseSeconds\x12\x19\n" +
+	"\brun_once\x18\x04 \x01(\bR\arunOnce\"[\n" +
+	"\vQueryResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\x12\"\n" +
+	"\x05query\x18\x03 \x01(\v2\f.proto.QueryR\x05query\"W\n" +
+	"\n" +
+	"ItemResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\x12\x1f\n" +
+	"\x04item\x18\x03 \x01(\v2\v.proto.ItemR\x04item\"[\n" +
+	"\vPriceResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\x12\"\n" +
+	"\x05price\x18\x03 \x01(\v2\f.proto.PriceR\x05price\"\xdc\x01\n" +
+	"\x12ListQueriesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x16\n" +
+	"\x06league\x18\x02 \x01(\tR\x06league\x12\x14\n" +
+	"\x05realm\x18\x03 \x01(\tR\x05realm\x12\x1e\n" +
+	"\brun_once\x18\x04 \x01(\bH\x00R\arunOnce\x88\x01\x01\x12\x17\n" +
+	"\aitem_id\x18\x05 \x01(\tR\x06itemId\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\v\n" +
+	"\t_run_once\"\xf0\x01\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06caller\x18\x03 \x01(\tR\x06caller\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x125\n" +
+	"\x06fields\x18\x05 \x03(\v2\x1d.proto.AuditEvent.FieldsEntryR\x06fields\x12\x12\n" +
+	"\x04code\x18\x06 \x01(\tR\x04code\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xba\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x16\n" +
+	"\x06caller\x18\x02 \x01(\tR\x06caller\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"`\n" +
+	"\vAuditEvents\x12)\n" +
+	"\x06events\x18\x01 \x03(\v2\x11.proto.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\x8f\x11\n" +
+	"\bDatabase\x12+\n" +
+	"\vInsertStats\x12\f.proto.Stats\x1a\f.proto.Empty\"\x00\x12.\n" +
+	"\n" +
+	"InsertItem\x12\v.proto.Item\x1a\x11.proto.ItemResult\"\x00\x124\n" +
+	"\x10InsertItemWithID\x12\v.proto.Item\x1a\x11.proto.ItemResult\"\x00\x121\n" +
+	"\vInsertQuery\x12\f.proto.Query\x1a\x12.proto.QueryResult\"\x00\x121\n" +
+	"\vUpsertQuery\x12\f.proto.Query\x1a\x12.proto.QueryResult\"\x00\x121\n" +
+	"\vInsertPrice\x12\f.proto.Price\x1a\x12.proto.PriceResult\"\x00\x129\n" +
+	"\x12InsertCurrencyRate\x12\x13.proto.CurrencyRate\x1a\f.proto.Empty\"\x00\x123\n" +
+	"\fInsertPrices\x12\r.proto.Prices\x1a\x12.proto.BatchResult\"\x00\x121\n" +
+	"\vInsertItems\x12\f.proto.Items\x1a\x12.proto.BatchResult\"\x00\x12;\n" +
+	"\x10InsertStatsBatch\x12\x11.proto.StatsBatch\x1a\x12.proto.BatchResult\"\x00\x126\n" +
+	"\fStreamPrices\x12\f.proto.Price\x1a\x14.proto.StreamSummary\"\x00(\x01\x127\n" +
+	"\aHasItem\x12\x15.proto.HasItemRequest\x1a\x13.proto.BoolResponse\"\x00\x126\n" +
+	"\aHasInfo\x12\x14.proto.ItemIDRequest\x1a\x13.proto.BoolResponse\"\x00\x12>\n" +
+	"\rHasPriceQuery\x12\x16.proto.HasPriceRequest\x1a\x13.proto.BoolResponse\"\x00\x12:\n" +
+	"\fGetBaseItems\x12\x16.proto.CategoryRequest\x1a\x10.proto.BaseItems\"\x00\x127\n" +
+	"\x0eGetInfoQueries\x12\x13.proto.LeaseRequest\x1a\x0e.proto.Queries\"\x00\x128\n" +
+	"\x0fGetPriceQueries\x12\x13.proto.LeaseRequest\x1a\x0e.proto.Queries\"\x00\x12<\n" +
+	"\fWatchQueries\x12\x1a.proto.WatchQueriesRequest\x1a\f.proto.Query\"\x000\x01\x127\n" +
+	"\x06GetMod\x12\x14.proto.GetModRequest\x1a\x15.proto.GetModResponse\"\x00\x12;\n" +
+	"\vSearchItems\x12\x14.proto.SearchRequest\x1a\x14.proto.SearchResults\"\x00\x12<\n" +
+	"\x12GetItemsByCategory\x12\x16.proto.CategoryRequest\x1a\f.proto.Items\"\x00\x12@\n" +
+	"\x15StreamItemsByCategory\x12\x16.proto.CategoryRequest\x1a\v.proto.Item\"\x000\x01\x12D\n" +
+	"\x0fGetPriceHistory\x12\x1a.proto.PriceHistoryRequest\x1a\x13.proto.PriceHistory\"\x00\x12D\n" +
+	"\x0fGetPriceCandles\x12\x1a.proto.PriceCandlesRequest\x1a\x13.proto.PriceCandles\"\x00\x12D\n" +
+	"\x0fGetLatestPrices\x12\x1a.proto.LatestPricesRequest\x1a\x13.proto.LatestPrices\"\x00\x12-\n" +
+	"\x0eUpdateItemInfo\x12\v.proto.Item\x1a\f.proto.Empty\"\x00\x12-\n" +
+	"\rUpdateNextRun\x12\f.proto.Query\x1a\f.proto.Empty\"\x00\x128\n" +
+	"\vExtendLease\x12\x19.proto.ExtendLeaseRequest\x1a\f.proto.Query\"\x00\x129\n" +
+	"\rCompleteQuery\x12\x18.proto.QueryLeaseRequest\x1a\f.proto.Empty\"\x00\x124\n" +
+	"\tFailQuery\x12\x17.proto.FailQueryRequest\x1a\f.proto.Empty\"\x00\x127\n" +
+	"\x0fListDeadQueries\x12\x12.proto.PageRequest\x1a\x0e.proto.Queries\"\x00\x125\n" +
+	"\fRequeueQuery\x12\x15.proto.QueryIDRequest\x1a\f.proto.Empty\"\x00\x12<\n" +
+	"\x14ListDuplicateQueries\x12\x12.proto.PageRequest\x1a\x0e.proto.Queries\"\x00\x12:\n" +
+	"\vListQueries\x12\x19.proto.ListQueriesRequest\x1a\x0e.proto.Queries\"\x00\x123\n" +
+	"\n" +
+	"PauseQuery\x12\x15.proto.QueryIDRequest\x1a\f.proto.Query\"\x00\x124\n" +
+	"\vResumeQuery\x12\x15.proto.QueryIDRequest\x1a\f.proto.Query\"\x00\x12F\n" +
+	"\x0fListAuditEvents\x12\x1d.proto.ListAuditEventsRequest\x1a\x12.proto.AuditEvents\"\x00\x123\n" +
 	"\vDeleteQuery\x12\x14.proto.ItemIDRequest\x1a\f.proto.Empty\"\x00B\x1dZ\x1bgithub.com/Vyary/rdpc/protob\x06proto3"
 
 var (
@@ -1247,66 +3722,159 @@ func file_proto_rdpc_proto_rawDescGZIP() []byte {
 	return file_proto_rdpc_proto_rawDescData
 }
 
-var file_proto_rdpc_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_rdpc_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_proto_rdpc_proto_goTypes = []any{
-	(*Stats)(nil),           // 0: proto.Stats
-	(*Item)(nil),            // 1: proto.Item
-	(*Query)(nil),           // 2: proto.Query
-	(*Price)(nil),           // 3: proto.Price
-	(*BaseItem)(nil),        // 4: proto.BaseItem
-	(*HasItemRequest)(nil),  // 5: proto.HasItemRequest
-	(*ItemIDRequest)(nil),   // 6: proto.ItemIDRequest
-	(*HasPriceRequest)(nil), // 7: proto.HasPriceRequest
-	(*Empty)(nil),           // 8: proto.Empty
-	(*BoolResponse)(nil),    // 9: proto.BoolResponse
-	(*CategoryRequest)(nil), // 10: proto.CategoryRequest
-	(*Queries)(nil),         // 11: proto.Queries
-	(*Items)(nil),           // 12: proto.Items
-	(*BaseItems)(nil),       // 13: proto.BaseItems
-	(*GetModRequest)(nil),   // 14: proto.GetModRequest
-	(*GetModResponse)(nil),  // 15: proto.GetModResponse
+	(*Stats)(nil),                  // 0: proto.Stats
+	(*Item)(nil),                   // 1: proto.Item
+	(*Query)(nil),                  // 2: proto.Query
+	(*Price)(nil),                  // 3: proto.Price
+	(*BaseItem)(nil),               // 4: proto.BaseItem
+	(*HasItemRequest)(nil),         // 5: proto.HasItemRequest
+	(*ItemIDRequest)(nil),          // 6: proto.ItemIDRequest
+	(*HasPriceRequest)(nil),        // 7: proto.HasPriceRequest
+	(*Empty)(nil),                  // 8: proto.Empty
+	(*BoolResponse)(nil),           // 9: proto.BoolResponse
+	(*CategoryRequest)(nil),        // 10: proto.CategoryRequest
+	(*Queries)(nil),                // 11: proto.Queries
+	(*Items)(nil),                  // 12: proto.Items
+	(*BaseItems)(nil),              // 13: proto.BaseItems
+	(*GetModRequest)(nil),          // 14: proto.GetModRequest
+	(*GetModResponse)(nil),         // 15: proto.GetModResponse
+	(*PriceHistoryRequest)(nil),    // 16: proto.PriceHistoryRequest
+	(*PriceHistory)(nil),           // 17: proto.PriceHistory
+	(*PriceCandlesRequest)(nil),    // 18: proto.PriceCandlesRequest
+	(*PriceCandle)(nil),            // 19: proto.PriceCandle
+	(*PriceCandles)(nil),           // 20: proto.PriceCandles
+	(*LatestPricesRequest)(nil),    // 21: proto.LatestPricesRequest
+	(*LatestPrice)(nil),            // 22: proto.LatestPrice
+	(*LatestPrices)(nil),           // 23: proto.LatestPrices
+	(*CurrencyRate)(nil),           // 24: proto.CurrencyRate
+	(*Prices)(nil),                 // 25: proto.Prices
+	(*StatsBatch)(nil),             // 26: proto.StatsBatch
+	(*RowResult)(nil),              // 27: proto.RowResult
+	(*BatchResult)(nil),            // 28: proto.BatchResult
+	(*StreamSummary)(nil),          // 29: proto.StreamSummary
+	(*SearchRequest)(nil),          // 30: proto.SearchRequest
+	(*ItemHit)(nil),                // 31: proto.ItemHit
+	(*ModHit)(nil),                 // 32: proto.ModHit
+	(*SearchResults)(nil),          // 33: proto.SearchResults
+	(*LeaseRequest)(nil),           // 34: proto.LeaseRequest
+	(*ExtendLeaseRequest)(nil),     // 35: proto.ExtendLeaseRequest
+	(*QueryLeaseRequest)(nil),      // 36: proto.QueryLeaseRequest
+	(*FailQueryRequest)(nil),       // 37: proto.FailQueryRequest
+	(*PageRequest)(nil),            // 38: proto.PageRequest
+	(*QueryIDRequest)(nil),         // 39: proto.QueryIDRequest
+	(*WatchQueriesRequest)(nil),    // 40: proto.WatchQueriesRequest
+	(*QueryResult)(nil),            // 41: proto.QueryResult
+	(*ItemResult)(nil),             // 42: proto.ItemResult
+	(*PriceResult)(nil),            // 43: proto.PriceResult
+	(*ListQueriesRequest)(nil),     // 44: proto.ListQueriesRequest
+	(*AuditEvent)(nil),             // 45: proto.AuditEvent
+	(*ListAuditEventsRequest)(nil), // 46: proto.ListAuditEventsRequest
+	(*AuditEvents)(nil),            // 47: proto.AuditEvents
+	nil,                            // 48: proto.AuditEvent.FieldsEntry
 }
 var file_proto_rdpc_proto_depIdxs = []int32{
 	2,  // 0: proto.Queries.queries:type_name -> proto.Query
 	1,  // 1: proto.Items.items:type_name -> proto.Item
 	4,  // 2: proto.BaseItems.items:type_name -> proto.BaseItem
-	0,  // 3: proto.Database.InsertStats:input_type -> proto.Stats
-	1,  // 4: proto.Database.InsertItem:input_type -> proto.Item
-	1,  // 5: proto.Database.InsertItemWithID:input_type -> proto.Item
-	2,  // 6: proto.Database.InsertQuery:input_type -> proto.Query
-	3,  // 7: proto.Database.InsertPrice:input_type -> proto.Price
-	5,  // 8: proto.Database.HasItem:input_type -> proto.HasItemRequest
-	6,  // 9: proto.Database.HasInfo:input_type -> proto.ItemIDRequest
-	7,  // 10: proto.Database.HasPriceQuery:input_type -> proto.HasPriceRequest
-	10, // 11: proto.Database.GetBaseItems:input_type -> proto.CategoryRequest
-	8,  // 12: proto.Database.GetInfoQueries:input_type -> proto.Empty
-	8,  // 13: proto.Database.GetPriceQueries:input_type -> proto.Empty
-	14, // 14: proto.Database.GetMod:input_type -> proto.GetModRequest
-	10, // 15: proto.Database.GetItemsByCategory:input_type -> proto.CategoryRequest
-	1,  // 16: proto.Database.UpdateItemInfo:input_type -> proto.Item
-	2,  // 17: proto.Database.UpdateNextRun:input_type -> proto.Query
-	6,  // 18: proto.Database.DeleteQuery:input_type -> proto.ItemIDRequest
-	8,  // 19: proto.Database.InsertStats:output_type -> proto.Empty
-	8,  // 20: proto.Database.InsertItem:output_type -> proto.Empty
-	8,  // 21: proto.Database.InsertItemWithID:output_type -> proto.Empty
-	8,  // 22: proto.Database.InsertQuery:output_type -> proto.Empty
-	8,  // 23: proto.Database.InsertPrice:output_type -> proto.Empty
-	9,  // 24: proto.Database.HasItem:output_type -> proto.BoolResponse
-	9,  // 25: proto.Database.HasInfo:output_type -> proto.BoolResponse
-	9,  // 26: proto.Database.HasPriceQuery:output_type -> proto.BoolResponse
-	13, // 27: proto.Database.GetBaseItems:output_type -> proto.BaseItems
-	11, // 28: proto.Database.GetInfoQueries:output_type -> proto.Queries
-	11, // 29: proto.Database.GetPriceQueries:output_type -> proto.Queries
-	15, // 30: proto.Database.GetMod:output_type -> proto.GetModResponse
-	12, // 31: proto.Database.GetItemsByCategory:output_type -> proto.Items
-	8,  // 32: proto.Database.UpdateItemInfo:output_type -> proto.Empty
-	8,  // 33: proto.Database.UpdateNextRun:output_type -> proto.Empty
-	8,  // 34: proto.Database.DeleteQuery:output_type -> proto.Empty
-	19, // [19:35] is the sub-list for method output_type
-	3,  // [3:19] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	3,  // 3: proto.PriceHistory.prices:type_name -> proto.Price
+	19, // 4: proto.PriceCandles.candles:type_name -> proto.PriceCandle
+	4,  // 5: proto.LatestPrice.item:type_name -> proto.BaseItem
+	22, // 6: proto.LatestPrices.prices:type_name -> proto.LatestPrice
+	3,  // 7: proto.Prices.prices:type_name -> proto.Price
+	0,  // 8: proto.StatsBatch.stats:type_name -> proto.Stats
+	27, // 9: proto.BatchResult.results:type_name -> proto.RowResult
+	4,  // 10: proto.ItemHit.item:type_name -> proto.BaseItem
+	0,  // 11: proto.ModHit.stat:type_name -> proto.Stats
+	31, // 12: proto.SearchResults.items:type_name -> proto.ItemHit
+	32, // 13: proto.SearchResults.mods:type_name -> proto.ModHit
+	2,  // 14: proto.QueryResult.query:type_name -> proto.Query
+	1,  // 15: proto.ItemResult.item:type_name -> proto.Item
+	3,  // 16: proto.PriceResult.price:type_name -> proto.Price
+	48, // 17: proto.AuditEvent.fields:type_name -> proto.AuditEvent.FieldsEntry
+	45, // 18: proto.AuditEvents.events:type_name -> proto.AuditEvent
+	0,  // 19: proto.Database.InsertStats:input_type -> proto.Stats
+	1,  // 20: proto.Database.InsertItem:input_type -> proto.Item
+	1,  // 21: proto.Database.InsertItemWithID:input_type -> proto.Item
+	2,  // 22: proto.Database.InsertQuery:input_type -> proto.Query
+	2,  // 23: proto.Database.UpsertQuery:input_type -> proto.Query
+	3,  // 24: proto.Database.InsertPrice:input_type -> proto.Price
+	24, // 25: proto.Database.InsertCurrencyRate:input_type -> proto.CurrencyRate
+	25, // 26: proto.Database.InsertPrices:input_type -> proto.Prices
+	12, // 27: proto.Database.InsertItems:input_type -> proto.Items
+	26, // 28: proto.Database.InsertStatsBatch:input_type -> proto.StatsBatch
+	3,  // 29: proto.Database.StreamPrices:input_type -> proto.Price
+	5,  // 30: proto.Database.HasItem:input_type -> proto.HasItemRequest
+	6,  // 31: proto.Database.HasInfo:input_type -> proto.ItemIDRequest
+	7,  // 32: proto.Database.HasPriceQuery:input_type -> proto.HasPriceRequest
+	10, // 33: proto.Database.GetBaseItems:input_type -> proto.CategoryRequest
+	34, // 34: proto.Database.GetInfoQueries:input_type -> proto.LeaseRequest
+	34, // 35: proto.Database.GetPriceQueries:input_type -> proto.LeaseRequest
+	40, // 36: proto.Database.WatchQueries:input_type -> proto.WatchQueriesRequest
+	14, // 37: proto.Database.GetMod:input_type -> proto.GetModRequest
+	30, // 38: proto.Database.SearchItems:input_type -> proto.SearchRequest
+	10, // 39: proto.Database.GetItemsByCategory:input_type -> proto.CategoryRequest
+	10, // 40: proto.Database.StreamItemsByCategory:input_type -> proto.CategoryRequest
+	16, // 41: proto.Database.GetPriceHistory:input_type -> proto.PriceHistoryRequest
+	18, // 42: proto.Database.GetPriceCandles:input_type -> proto.PriceCandlesRequest
+	21, // 43: proto.Database.GetLatestPrices:input_type -> proto.LatestPricesRequest
+	1,  // 44: proto.Database.UpdateItemInfo:input_type -> proto.Item
+	2,  // 45: proto.Database.UpdateNextRun:input_type -> proto.Query
+	35, // 46: proto.Database.ExtendLease:input_type -> proto.ExtendLeaseRequest
+	36, // 47: proto.Database.CompleteQuery:input_type -> proto.QueryLeaseRequest
+	37, // 48: proto.Database.FailQuery:input_type -> proto.FailQueryRequest
+	38, // 49: proto.Database.ListDeadQueries:input_type -> proto.PageRequest
+	39, // 50: proto.Database.RequeueQuery:input_type -> proto.QueryIDRequest
+	38, // 51: proto.Database.ListDuplicateQueries:input_type -> proto.PageRequest
+	44, // 52: proto.Database.ListQueries:input_type -> proto.ListQueriesRequest
+	39, // 53: proto.Database.PauseQuery:input_type -> proto.QueryIDRequest
+	39, // 54: proto.Database.ResumeQuery:input_type -> proto.QueryIDRequest
+	46, // 55: proto.Database.ListAuditEvents:input_type -> proto.ListAuditEventsRequest
+	6,  // 56: proto.Database.DeleteQuery:input_type -> proto.ItemIDRequest
+	8,  // 57: proto.Database.InsertStats:output_type -> proto.Empty
+	42, // 58: proto.Database.InsertItem:output_type -> proto.ItemResult
+	42, // 59: proto.Database.InsertItemWithID:output_type -> proto.ItemResult
+	41, // 60: proto.Database.InsertQuery:output_type -> proto.QueryResult
+	41, // 61: proto.Database.UpsertQuery:output_type -> proto.QueryResult
+	43, // 62: proto.Database.InsertPrice:output_type -> proto.PriceResult
+	8,  // 63: proto.Database.InsertCurrencyRate:output_type -> proto.Empty
+	28, // 64: proto.Database.InsertPrices:output_type -> proto.BatchResult
+	28, // 65: proto.Database.InsertItems:output_type -> proto.BatchResult
+	28, // 66: proto.Database.InsertStatsBatch:output_type -> proto.BatchResult
+	29, // 67: proto.Database.StreamPrices:output_type -> proto.StreamSummary
+	9,  // 68: proto.Database.HasItem:output_type -> proto.BoolResponse
+	9,  // 69: proto.Database.HasInfo:output_type -> proto.BoolResponse
+	9,  // 70: proto.Database.HasPriceQuery:output_type -> proto.BoolResponse
+	13, // 71: proto.Database.GetBaseItems:output_type -> proto.BaseItems
+	11, // 72: proto.Database.GetInfoQueries:output_type -> proto.Queries
+	11, // 73: proto.Database.GetPriceQueries:output_type -> proto.Queries
+	2,  // 74: proto.Database.WatchQueries:output_type -> proto.Query
+	15, // 75: proto.Database.GetMod:output_type -> proto.GetModResponse
+	33, // 76: proto.Database.SearchItems:output_type -> proto.SearchResults
+	12, // 77: proto.Database.GetItemsByCategory:output_type -> proto.Items
+	1,  // 78: proto.Database.StreamItemsByCategory:output_type -> proto.Item
+	17, // 79: proto.Database.GetPriceHistory:output_type -> proto.PriceHistory
+	20, // 80: proto.Database.GetPriceCandles:output_type -> proto.PriceCandles
+	23, // 81: proto.Database.GetLatestPrices:output_type -> proto.LatestPrices
+	8,  // 82: proto.Database.UpdateItemInfo:output_type -> proto.Empty
+	8,  // 83: proto.Database.UpdateNextRun:output_type -> proto.Empty
+	2,  // 84: proto.Database.ExtendLease:output_type -> proto.Query
+	8,  // 85: proto.Database.CompleteQuery:output_type -> proto.Empty
+	8,  // 86: proto.Database.FailQuery:output_type -> proto.Empty
+	11, // 87: proto.Database.ListDeadQueries:output_type -> proto.Queries
+	8,  // 88: proto.Database.RequeueQuery:output_type -> proto.Empty
+	11, // 89: proto.Database.ListDuplicateQueries:output_type -> proto.Queries
+	11, // 90: proto.Database.ListQueries:output_type -> proto.Queries
+	2,  // 91: proto.Database.PauseQuery:output_type -> proto.Query
+	2,  // 92: proto.Database.ResumeQuery:output_type -> proto.Query
+	47, // 93: proto.Database.ListAuditEvents:output_type -> proto.AuditEvents
+	8,  // 94: proto.Database.DeleteQuery:output_type -> proto.Empty
+	57, // [57:95] is the sub-list for method output_type
+	19, // [19:57] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_rdpc_proto_init() }
@@ -1314,13 +3882,15 @@ func file_proto_rdpc_proto_init() {
 	if File_proto_rdpc_proto != nil {
 		return
 	}
+	file_proto_rdpc_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_rdpc_proto_msgTypes[44].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_rdpc_proto_rawDesc), len(file_proto_rdpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
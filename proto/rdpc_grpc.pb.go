@@ -19,22 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Database_InsertStats_FullMethodName        = "/proto.Database/InsertStats"
-	Database_InsertItem_FullMethodName         = "/proto.Database/InsertItem"
-	Database_InsertItemWithID_FullMethodName   = "/proto.Database/InsertItemWithID"
-	Database_InsertQuery_FullMethodName        = "/proto.Database/InsertQuery"
-	Database_InsertPrice_FullMethodName        = "/proto.Database/InsertPrice"
-	Database_HasItem_FullMethodName            = "/proto.Database/HasItem"
-	Database_HasInfo_FullMethodName            = "/proto.Database/HasInfo"
-	Database_HasPriceQuery_FullMethodName      = "/proto.Database/HasPriceQuery"
-	Database_GetBaseItems_FullMethodName       = "/proto.Database/GetBaseItems"
-	Database_GetInfoQueries_FullMethodName     = "/proto.Database/GetInfoQueries"
-	Database_GetPriceQueries_FullMethodName    = "/proto.Database/GetPriceQueries"
-	Database_GetMod_FullMethodName             = "/proto.Database/GetMod"
-	Database_GetItemsByCategory_FullMethodName = "/proto.Database/GetItemsByCategory"
-	Database_UpdateItemInfo_FullMethodName     = "/proto.Database/UpdateItemInfo"
-	Database_UpdateNextRun_FullMethodName      = "/proto.Database/UpdateNextRun"
-	Database_DeleteQuery_FullMethodName        = "/proto.Database/DeleteQuery"
+	Database_InsertStats_FullMethodName           = "/proto.Database/InsertStats"
+	Database_InsertItem_FullMethodName            = "/proto.Database/InsertItem"
+	Database_InsertItemWithID_FullMethodName      = "/proto.Database/InsertItemWithID"
+	Database_InsertQuery_FullMethodName           = "/proto.Database/InsertQuery"
+	Database_UpsertQuery_FullMethodName           = "/proto.Database/UpsertQuery"
+	Database_InsertPrice_FullMethodName           = "/proto.Database/InsertPrice"
+	Database_InsertCurrencyRate_FullMethodName    = "/proto.Database/InsertCurrencyRate"
+	Database_InsertPrices_FullMethodName          = "/proto.Database/InsertPrices"
+	Database_InsertItems_FullMethodName           = "/proto.Database/InsertItems"
+	Database_InsertStatsBatch_FullMethodName      = "/proto.Database/InsertStatsBatch"
+	Database_StreamPrices_FullMethodName          = "/proto.Database/StreamPrices"
+	Database_HasItem_FullMethodName               = "/proto.Database/HasItem"
+	Database_HasInfo_FullMethodName               = "/proto.Database/HasInfo"
+	Database_HasPriceQuery_FullMethodName         = "/proto.Database/HasPriceQuery"
+	Database_GetBaseItems_FullMethodName          = "/proto.Database/GetBaseItems"
+	Database_GetInfoQueries_FullMethodName        = "/proto.Database/GetInfoQueries"
+	Database_GetPriceQueries_FullMethodName       = "/proto.Database/GetPriceQueries"
+	Database_WatchQueries_FullMethodName          = "/proto.Database/WatchQueries"
+	Database_GetMod_FullMethodName                = "/proto.Database/GetMod"
+	Database_SearchItems_FullMethodName           = "/proto.Database/SearchItems"
+	Database_GetItemsByCategory_FullMethodName    = "/proto.Database/GetItemsByCategory"
+	Database_StreamItemsByCategory_FullMethodName = "/proto.Database/StreamItemsByCategory"
+	Database_GetPriceHistory_FullMethodName       = "/proto.Database/GetPriceHistory"
+	Database_GetPriceCandles_FullMethodName       = "/proto.Database/GetPriceCandles"
+	Database_GetLatestPrices_FullMethodName       = "/proto.Database/GetLatestPrices"
+	Database_UpdateItemInfo_FullMethodName        = "/proto.Database/UpdateItemInfo"
+	Database_UpdateNextRun_FullMethodName         = "/proto.Database/UpdateNextRun"
+	Database_ExtendLease_FullMethodName           = "/proto.Database/ExtendLease"
+	Database_CompleteQuery_FullMethodName         = "/proto.Database/CompleteQuery"
+	Database_FailQuery_FullMethodName             = "/proto.Database/FailQuery"
+	Database_ListDeadQueries_FullMethodName       = "/proto.Database/ListDeadQueries"
+	Database_RequeueQuery_FullMethodName          = "/proto.Database/RequeueQuery"
+	Database_ListDuplicateQueries_FullMethodName  = "/proto.Database/ListDuplicateQueries"
+	Database_ListQueries_FullMethodName           = "/proto.Database/ListQueries"
+	Database_PauseQuery_FullMethodName            = "/proto.Database/PauseQuery"
+	Database_ResumeQuery_FullMethodName           = "/proto.Database/ResumeQuery"
+	Database_ListAuditEvents_FullMethodName       = "/proto.Database/ListAuditEvents"
+	Database_DeleteQuery_FullMethodName           = "/proto.Database/DeleteQuery"
 )
 
 // DatabaseClient is the client API for Database service.
@@ -42,20 +64,42 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DatabaseClient interface {
 	InsertStats(ctx context.Context, in *Stats, opts ...grpc.CallOption) (*Empty, error)
-	InsertItem(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Empty, error)
-	InsertItemWithID(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Empty, error)
-	InsertQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*Empty, error)
-	InsertPrice(ctx context.Context, in *Price, opts ...grpc.CallOption) (*Empty, error)
+	InsertItem(ctx context.Context, in *Item, opts ...grpc.CallOption) (*ItemResult, error)
+	InsertItemWithID(ctx context.Context, in *Item, opts ...grpc.CallOption) (*ItemResult, error)
+	InsertQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*QueryResult, error)
+	UpsertQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*QueryResult, error)
+	InsertPrice(ctx context.Context, in *Price, opts ...grpc.CallOption) (*PriceResult, error)
+	InsertCurrencyRate(ctx context.Context, in *CurrencyRate, opts ...grpc.CallOption) (*Empty, error)
+	InsertPrices(ctx context.Context, in *Prices, opts ...grpc.CallOption) (*BatchResult, error)
+	InsertItems(ctx context.Context, in *Items, opts ...grpc.CallOption) (*BatchResult, error)
+	InsertStatsBatch(ctx context.Context, in *StatsBatch, opts ...grpc.CallOption) (*BatchResult, error)
+	StreamPrices(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Price, StreamSummary], error)
 	HasItem(ctx context.Context, in *HasItemRequest, opts ...grpc.CallOption) (*BoolResponse, error)
 	HasInfo(ctx context.Context, in *ItemIDRequest, opts ...grpc.CallOption) (*BoolResponse, error)
 	HasPriceQuery(ctx context.Context, in *HasPriceRequest, opts ...grpc.CallOption) (*BoolResponse, error)
 	GetBaseItems(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*BaseItems, error)
-	GetInfoQueries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Queries, error)
-	GetPriceQueries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Queries, error)
+	GetInfoQueries(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*Queries, error)
+	GetPriceQueries(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*Queries, error)
+	WatchQueries(ctx context.Context, in *WatchQueriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Query], error)
 	GetMod(ctx context.Context, in *GetModRequest, opts ...grpc.CallOption) (*GetModResponse, error)
+	SearchItems(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResults, error)
 	GetItemsByCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*Items, error)
+	StreamItemsByCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Item], error)
+	GetPriceHistory(ctx context.Context, in *PriceHistoryRequest, opts ...grpc.CallOption) (*PriceHistory, error)
+	GetPriceCandles(ctx context.Context, in *PriceCandlesRequest, opts ...grpc.CallOption) (*PriceCandles, error)
+	GetLatestPrices(ctx context.Context, in *LatestPricesRequest, opts ...grpc.CallOption) (*LatestPrices, error)
 	UpdateItemInfo(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Empty, error)
 	UpdateNextRun(ctx context.Context, in *Query, opts ...grpc.CallOption) (*Empty, error)
+	ExtendLease(ctx context.Context, in *ExtendLeaseRequest, opts ...grpc.CallOption) (*Query, error)
+	CompleteQuery(ctx context.Context, in *QueryLeaseRequest, opts ...grpc.CallOption) (*Empty, error)
+	FailQuery(ctx context.Context, in *FailQueryRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListDeadQueries(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*Queries, error)
+	RequeueQuery(ctx context.Context, in *QueryIDRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListDuplicateQueries(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*Queries, error)
+	ListQueries(ctx context.Context, in *ListQueriesRequest, opts ...grpc.CallOption) (*Queries, error)
+	PauseQuery(ctx context.Context, in *QueryIDRequest, opts ...grpc.CallOption) (*Query, error)
+	ResumeQuery(ctx context.Context, in *QueryIDRequest, opts ...grpc.CallOption) (*Query, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*AuditEvents, error)
 	DeleteQuery(ctx context.Context, in *ItemIDRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
@@ -77,9 +121,9 @@ func (c *databaseClient) InsertStats(ctx context.Context, in *Stats, opts ...grp
 	return out, nil
 }
 
-func (c *databaseClient) InsertItem(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Empty, error) {
+func (c *databaseClient) InsertItem(ctx context.Context, in *Item, opts ...grpc.CallOption) (*ItemResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(ItemResult)
 	err := c.cc.Invoke(ctx, Database_InsertItem_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -87,9 +131,9 @@ func (c *databaseClient) InsertItem(ctx context.Context, in *Item, opts ...grpc.
 	return out, nil
 }
 
-func (c *databaseClient) InsertItemWithID(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Empty, error) {
+func (c *databaseClient) InsertItemWithID(ctx context.Context, in *Item, opts ...grpc.CallOption) (*ItemResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(ItemResult)
 	err := c.cc.Invoke(ctx, Database_InsertItemWithID_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -97,9 +141,9 @@ func (c *databaseClient) InsertItemWithID(ctx context.Context, in *Item, opts ..
 	return out, nil
 }
 
-func (c *databaseClient) InsertQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*Empty, error) {
+func (c *databaseClient) InsertQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*QueryResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(QueryResult)
 	err := c.cc.Invoke(ctx, Database_InsertQuery_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -107,9 +151,19 @@ func (c *databaseClient) InsertQuery(ctx context.Context, in *Query, opts ...grp
 	return out, nil
 }
 
-func (c *databaseClient) InsertPrice(ctx context.Context, in *Price, opts ...grpc.CallOption) (*Empty, error) {
+func (c *databaseClient) UpsertQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*QueryResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(QueryResult)
+	err := c.cc.Invoke(ctx, Database_UpsertQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) InsertPrice(ctx context.Context, in *Price, opts ...grpc.CallOption) (*PriceResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceResult)
 	err := c.cc.Invoke(ctx, Database_InsertPrice_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -117,6 +171,59 @@ func (c *databaseClient) InsertPrice(ctx context.Context, in *Price, opts ...grp
 	return out, nil
 }
 
+func (c *databaseClient) InsertCurrencyRate(ctx context.Context, in *CurrencyRate, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Database_InsertCurrencyRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) InsertPrices(ctx context.Context, in *Prices, opts ...grpc.CallOption) (*BatchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResult)
+	err := c.cc.Invoke(ctx, Database_InsertPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) InsertItems(ctx context.Context, in *Items, opts ...grpc.CallOption) (*BatchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResult)
+	err := c.cc.Invoke(ctx, Database_InsertItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) InsertStatsBatch(ctx context.Context, in *StatsBatch, opts ...grpc.CallOption) (*BatchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResult)
+	err := c.cc.Invoke(ctx, Database_InsertStatsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) StreamPrices(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Price, StreamSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[0], Database_StreamPrices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Price, StreamSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Database_StreamPricesClient = grpc.ClientStreamingClient[Price, StreamSummary]
+
 func (c *databaseClient) HasItem(ctx context.Context, in *HasItemRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BoolResponse)
@@ -157,7 +264,7 @@ func (c *databaseClient) GetBaseItems(ctx context.Context, in *CategoryRequest,
 	return out, nil
 }
 
-func (c *databaseClient) GetInfoQueries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Queries, error) {
+func (c *databaseClient) GetInfoQueries(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*Queries, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Queries)
 	err := c.cc.Invoke(ctx, Database_GetInfoQueries_FullMethodName, in, out, cOpts...)
@@ -167,7 +274,7 @@ func (c *databaseClient) GetInfoQueries(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
-func (c *databaseClient) GetPriceQueries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Queries, error) {
+func (c *databaseClient) GetPriceQueries(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*Queries, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Queries)
 	err := c.cc.Invoke(ctx, Database_GetPriceQueries_FullMethodName, in, out, cOpts...)
@@ -177,6 +284,25 @@ func (c *databaseClient) GetPriceQueries(ctx context.Context, in *Empty, opts ..
 	return out, nil
 }
 
+func (c *databaseClient) WatchQueries(ctx context.Context, in *WatchQueriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Query], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[1], Database_WatchQueries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchQueriesRequest, Query]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Database_WatchQueriesClient = grpc.ServerStreamingClient[Query]
+
 func (c *databaseClient) GetMod(ctx context.Context, in *GetModRequest, opts ...grpc.CallOption) (*GetModResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetModResponse)
@@ -187,6 +313,16 @@ func (c *databaseClient) GetMod(ctx context.Context, in *GetModRequest, opts ...
 	return out, nil
 }
 
+func (c *databaseClient) SearchItems(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResults)
+	err := c.cc.Invoke(ctx, Database_SearchItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) GetItemsByCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*Items, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Items)
@@ -197,6 +333,55 @@ func (c *databaseClient) GetItemsByCategory(ctx context.Context, in *CategoryReq
 	return out, nil
 }
 
+func (c *databaseClient) StreamItemsByCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Item], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[2], Database_StreamItemsByCategory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CategoryRequest, Item]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Database_StreamItemsByCategoryClient = grpc.ServerStreamingClient[Item]
+
+func (c *databaseClient) GetPriceHistory(ctx context.Context, in *PriceHistoryRequest, opts ...grpc.CallOption) (*PriceHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceHistory)
+	err := c.cc.Invoke(ctx, Database_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) GetPriceCandles(ctx context.Context, in *PriceCandlesRequest, opts ...grpc.CallOption) (*PriceCandles, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceCandles)
+	err := c.cc.Invoke(ctx, Database_GetPriceCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) GetLatestPrices(ctx context.Context, in *LatestPricesRequest, opts ...grpc.CallOption) (*LatestPrices, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LatestPrices)
+	err := c.cc.Invoke(ctx, Database_GetLatestPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) UpdateItemInfo(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -217,6 +402,106 @@ func (c *databaseClient) UpdateNextRun(ctx context.Context, in *Query, opts ...g
 	return out, nil
 }
 
+func (c *databaseClient) ExtendLease(ctx context.Context, in *ExtendLeaseRequest, opts ...grpc.CallOption) (*Query, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Query)
+	err := c.cc.Invoke(ctx, Database_ExtendLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) CompleteQuery(ctx context.Context, in *QueryLeaseRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Database_CompleteQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) FailQuery(ctx context.Context, in *FailQueryRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Database_FailQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListDeadQueries(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*Queries, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queries)
+	err := c.cc.Invoke(ctx, Database_ListDeadQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) RequeueQuery(ctx context.Context, in *QueryIDRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Database_RequeueQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListDuplicateQueries(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*Queries, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queries)
+	err := c.cc.Invoke(ctx, Database_ListDuplicateQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListQueries(ctx context.Context, in *ListQueriesRequest, opts ...grpc.CallOption) (*Queries, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queries)
+	err := c.cc.Invoke(ctx, Database_ListQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) PauseQuery(ctx context.Context, in *QueryIDRequest, opts ...grpc.CallOption) (*Query, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Query)
+	err := c.cc.Invoke(ctx, Database_PauseQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ResumeQuery(ctx context.Context, in *QueryIDRequest, opts ...grpc.CallOption) (*Query, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Query)
+	err := c.cc.Invoke(ctx, Database_ResumeQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*AuditEvents, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditEvents)
+	err := c.cc.Invoke(ctx, Database_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) DeleteQuery(ctx context.Context, in *ItemIDRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -232,20 +517,42 @@ func (c *databaseClient) DeleteQuery(ctx context.Context, in *ItemIDRequest, opt
 // for forward compatibility.
 type DatabaseServer interface {
 	InsertStats(context.Context, *Stats) (*Empty, error)
-	InsertItem(context.Context, *Item) (*Empty, error)
-	InsertItemWithID(context.Context, *Item) (*Empty, error)
-	InsertQuery(context.Context, *Query) (*Empty, error)
-	InsertPrice(context.Context, *Price) (*Empty, error)
+	InsertItem(context.Context, *Item) (*ItemResult, error)
+	InsertItemWithID(context.Context, *Item) (*ItemResult, error)
+	InsertQuery(context.Context, *Query) (*QueryResult, error)
+	UpsertQuery(context.Context, *Query) (*QueryResult, error)
+	InsertPrice(context.Context, *Price) (*PriceResult, error)
+	InsertCurrencyRate(context.Context, *CurrencyRate) (*Empty, error)
+	InsertPrices(context.Context, *Prices) (*BatchResult, error)
+	InsertItems(context.Context, *Items) (*BatchResult, error)
+	InsertStatsBatch(context.Context, *StatsBatch) (*BatchResult, error)
+	StreamPrices(grpc.ClientStreamingServer[Price, StreamSummary]) error
 	HasItem(context.Context, *HasItemRequest) (*BoolResponse, error)
 	HasInfo(context.Context, *ItemIDRequest) (*BoolResponse, error)
 	HasPriceQuery(context.Context, *HasPriceRequest) (*BoolResponse, error)
 	GetBaseItems(context.Context, *CategoryRequest) (*BaseItems, error)
-	GetInfoQueries(context.Context, *Empty) (*Queries, error)
-	GetPriceQueries(context.Context, *Empty) (*Queries, error)
+	GetInfoQueries(context.Context, *LeaseRequest) (*Queries, error)
+	GetPriceQueries(context.Context, *LeaseRequest) (*Queries, error)
+	WatchQueries(*WatchQueriesRequest, grpc.ServerStreamingServer[Query]) error
 	GetMod(context.Context, *GetModRequest) (*GetModResponse, error)
+	SearchItems(context.Context, *SearchRequest) (*SearchResults, error)
 	GetItemsByCategory(context.Context, *CategoryRequest) (*Items, error)
+	StreamItemsByCategory(*CategoryRequest, grpc.ServerStreamingServer[Item]) error
+	GetPriceHistory(context.Context, *PriceHistoryRequest) (*PriceHistory, error)
+	GetPriceCandles(context.Context, *PriceCandlesRequest) (*PriceCandles, error)
+	GetLatestPrices(context.Context, *LatestPricesRequest) (*LatestPrices, error)
 	UpdateItemInfo(context.Context, *Item) (*Empty, error)
 	UpdateNextRun(context.Context, *Query) (*Empty, error)
+	ExtendLease(context.Context, *ExtendLeaseRequest) (*Query, error)
+	CompleteQuery(context.Context, *QueryLeaseRequest) (*Empty, error)
+	FailQuery(context.Context, *FailQueryRequest) (*Empty, error)
+	ListDeadQueries(context.Context, *PageRequest) (*Queries, error)
+	RequeueQuery(context.Context, *QueryIDRequest) (*Empty, error)
+	ListDuplicateQueries(context.Context, *PageRequest) (*Queries, error)
+	ListQueries(context.Context, *ListQueriesRequest) (*Queries, error)
+	PauseQuery(context.Context, *QueryIDRequest) (*Query, error)
+	ResumeQuery(context.Context, *QueryIDRequest) (*Query, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*AuditEvents, error)
 	DeleteQuery(context.Context, *ItemIDRequest) (*Empty, error)
 	mustEmbedUnimplementedDatabaseServer()
 }
@@ -260,18 +567,36 @@ type UnimplementedDatabaseServer struct{}
 func (UnimplementedDatabaseServer) InsertStats(context.Context, *Stats) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InsertStats not implemented")
 }
-func (UnimplementedDatabaseServer) InsertItem(context.Context, *Item) (*Empty, error) {
+func (UnimplementedDatabaseServer) InsertItem(context.Context, *Item) (*ItemResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InsertItem not implemented")
 }
-func (UnimplementedDatabaseServer) InsertItemWithID(context.Context, *Item) (*Empty, error) {
+func (UnimplementedDatabaseServer) InsertItemWithID(context.Context, *Item) (*ItemResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InsertItemWithID not implemented")
 }
-func (UnimplementedDatabaseServer) InsertQuery(context.Context, *Query) (*Empty, error) {
+func (UnimplementedDatabaseServer) InsertQuery(context.Context, *Query) (*QueryResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InsertQuery not implemented")
 }
-func (UnimplementedDatabaseServer) InsertPrice(context.Context, *Price) (*Empty, error) {
+func (UnimplementedDatabaseServer) UpsertQuery(context.Context, *Query) (*QueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertQuery not implemented")
+}
+func (UnimplementedDatabaseServer) InsertPrice(context.Context, *Price) (*PriceResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InsertPrice not implemented")
 }
+func (UnimplementedDatabaseServer) InsertCurrencyRate(context.Context, *CurrencyRate) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertCurrencyRate not implemented")
+}
+func (UnimplementedDatabaseServer) InsertPrices(context.Context, *Prices) (*BatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertPrices not implemented")
+}
+func (UnimplementedDatabaseServer) InsertItems(context.Context, *Items) (*BatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertItems not implemented")
+}
+func (UnimplementedDatabaseServer) InsertStatsBatch(context.Context, *StatsBatch) (*BatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertStatsBatch not implemented")
+}
+func (UnimplementedDatabaseServer) StreamPrices(grpc.ClientStreamingServer[Price, StreamSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPrices not implemented")
+}
 func (UnimplementedDatabaseServer) HasItem(context.Context, *HasItemRequest) (*BoolResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HasItem not implemented")
 }
@@ -284,24 +609,72 @@ func (UnimplementedDatabaseServer) HasPriceQuery(context.Context, *HasPriceReque
 func (UnimplementedDatabaseServer) GetBaseItems(context.Context, *CategoryRequest) (*BaseItems, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBaseItems not implemented")
 }
-func (UnimplementedDatabaseServer) GetInfoQueries(context.Context, *Empty) (*Queries, error) {
+func (UnimplementedDatabaseServer) GetInfoQueries(context.Context, *LeaseRequest) (*Queries, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfoQueries not implemented")
 }
-func (UnimplementedDatabaseServer) GetPriceQueries(context.Context, *Empty) (*Queries, error) {
+func (UnimplementedDatabaseServer) GetPriceQueries(context.Context, *LeaseRequest) (*Queries, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPriceQueries not implemented")
 }
+func (UnimplementedDatabaseServer) WatchQueries(*WatchQueriesRequest, grpc.ServerStreamingServer[Query]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchQueries not implemented")
+}
 func (UnimplementedDatabaseServer) GetMod(context.Context, *GetModRequest) (*GetModResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMod not implemented")
 }
+func (UnimplementedDatabaseServer) SearchItems(context.Context, *SearchRequest) (*SearchResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchItems not implemented")
+}
 func (UnimplementedDatabaseServer) GetItemsByCategory(context.Context, *CategoryRequest) (*Items, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetItemsByCategory not implemented")
 }
+func (UnimplementedDatabaseServer) StreamItemsByCategory(*CategoryRequest, grpc.ServerStreamingServer[Item]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamItemsByCategory not implemented")
+}
+func (UnimplementedDatabaseServer) GetPriceHistory(context.Context, *PriceHistoryRequest) (*PriceHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedDatabaseServer) GetPriceCandles(context.Context, *PriceCandlesRequest) (*PriceCandles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceCandles not implemented")
+}
+func (UnimplementedDatabaseServer) GetLatestPrices(context.Context, *LatestPricesRequest) (*LatestPrices, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestPrices not implemented")
+}
 func (UnimplementedDatabaseServer) UpdateItemInfo(context.Context, *Item) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateItemInfo not implemented")
 }
 func (UnimplementedDatabaseServer) UpdateNextRun(context.Context, *Query) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateNextRun not implemented")
 }
+func (UnimplementedDatabaseServer) ExtendLease(context.Context, *ExtendLeaseRequest) (*Query, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendLease not implemented")
+}
+func (UnimplementedDatabaseServer) CompleteQuery(context.Context, *QueryLeaseRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteQuery not implemented")
+}
+func (UnimplementedDatabaseServer) FailQuery(context.Context, *FailQueryRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailQuery not implemented")
+}
+func (UnimplementedDatabaseServer) ListDeadQueries(context.Context, *PageRequest) (*Queries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadQueries not implemented")
+}
+func (UnimplementedDatabaseServer) RequeueQuery(context.Context, *QueryIDRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueQuery not implemented")
+}
+func (UnimplementedDatabaseServer) ListDuplicateQueries(context.Context, *PageRequest) (*Queries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDuplicateQueries not implemented")
+}
+func (UnimplementedDatabaseServer) ListQueries(context.Context, *ListQueriesRequest) (*Queries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueries not implemented")
+}
+func (UnimplementedDatabaseServer) PauseQuery(context.Context, *QueryIDRequest) (*Query, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseQuery not implemented")
+}
+func (UnimplementedDatabaseServer) ResumeQuery(context.Context, *QueryIDRequest) (*Query, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeQuery not implemented")
+}
+func (UnimplementedDatabaseServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*AuditEvents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedDatabaseServer) DeleteQuery(context.Context, *ItemIDRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteQuery not implemented")
 }
@@ -398,6 +771,24 @@ func _Database_InsertQuery_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_UpsertQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Query)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).UpsertQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_UpsertQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).UpsertQuery(ctx, req.(*Query))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_InsertPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Price)
 	if err := dec(in); err != nil {
@@ -416,6 +807,85 @@ func _Database_InsertPrice_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_InsertCurrencyRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrencyRate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).InsertCurrencyRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_InsertCurrencyRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).InsertCurrencyRate(ctx, req.(*CurrencyRate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_InsertPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Prices)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).InsertPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_InsertPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).InsertPrices(ctx, req.(*Prices))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_InsertItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Items)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).InsertItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_InsertItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).InsertItems(ctx, req.(*Items))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_InsertStatsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).InsertStatsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_InsertStatsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).InsertStatsBatch(ctx, req.(*StatsBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_StreamPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DatabaseServer).StreamPrices(&grpc.GenericServerStream[Price, StreamSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Database_StreamPricesServer = grpc.ClientStreamingServer[Price, StreamSummary]
+
 func _Database_HasItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HasItemRequest)
 	if err := dec(in); err != nil {
@@ -489,7 +959,7 @@ func _Database_GetBaseItems_Handler(srv interface{}, ctx context.Context, dec fu
 }
 
 func _Database_GetInfoQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(LeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -501,13 +971,13 @@ func _Database_GetInfoQueries_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: Database_GetInfoQueries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DatabaseServer).GetInfoQueries(ctx, req.(*Empty))
+		return srv.(DatabaseServer).GetInfoQueries(ctx, req.(*LeaseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _Database_GetPriceQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(LeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -519,11 +989,22 @@ func _Database_GetPriceQueries_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: Database_GetPriceQueries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DatabaseServer).GetPriceQueries(ctx, req.(*Empty))
+		return srv.(DatabaseServer).GetPriceQueries(ctx, req.(*LeaseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_WatchQueries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchQueriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).WatchQueries(m, &grpc.GenericServerStream[WatchQueriesRequest, Query]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Database_WatchQueriesServer = grpc.ServerStreamingServer[Query]
+
 func _Database_GetMod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetModRequest)
 	if err := dec(in); err != nil {
@@ -542,6 +1023,24 @@ func _Database_GetMod_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_SearchItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SearchItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_SearchItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SearchItems(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_GetItemsByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CategoryRequest)
 	if err := dec(in); err != nil {
@@ -560,6 +1059,71 @@ func _Database_GetItemsByCategory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_StreamItemsByCategory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CategoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).StreamItemsByCategory(m, &grpc.GenericServerStream[CategoryRequest, Item]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Database_StreamItemsByCategoryServer = grpc.ServerStreamingServer[Item]
+
+func _Database_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetPriceHistory(ctx, req.(*PriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_GetPriceCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PriceCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetPriceCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_GetPriceCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetPriceCandles(ctx, req.(*PriceCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_GetLatestPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatestPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetLatestPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_GetLatestPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetLatestPrices(ctx, req.(*LatestPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_UpdateItemInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Item)
 	if err := dec(in); err != nil {
@@ -596,6 +1160,186 @@ func _Database_UpdateNextRun_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_ExtendLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ExtendLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_ExtendLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ExtendLease(ctx, req.(*ExtendLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_CompleteQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).CompleteQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_CompleteQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).CompleteQuery(ctx, req.(*QueryLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_FailQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).FailQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_FailQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).FailQuery(ctx, req.(*FailQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListDeadQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ListDeadQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_ListDeadQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ListDeadQueries(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_RequeueQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).RequeueQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_RequeueQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).RequeueQuery(ctx, req.(*QueryIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListDuplicateQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ListDuplicateQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_ListDuplicateQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ListDuplicateQueries(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ListQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_ListQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ListQueries(ctx, req.(*ListQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_PauseQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).PauseQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_PauseQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).PauseQuery(ctx, req.(*QueryIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ResumeQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ResumeQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_ResumeQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ResumeQuery(ctx, req.(*QueryIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_DeleteQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ItemIDRequest)
 	if err := dec(in); err != nil {
@@ -637,10 +1381,30 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InsertQuery",
 			Handler:    _Database_InsertQuery_Handler,
 		},
+		{
+			MethodName: "UpsertQuery",
+			Handler:    _Database_UpsertQuery_Handler,
+		},
 		{
 			MethodName: "InsertPrice",
 			Handler:    _Database_InsertPrice_Handler,
 		},
+		{
+			MethodName: "InsertCurrencyRate",
+			Handler:    _Database_InsertCurrencyRate_Handler,
+		},
+		{
+			MethodName: "InsertPrices",
+			Handler:    _Database_InsertPrices_Handler,
+		},
+		{
+			MethodName: "InsertItems",
+			Handler:    _Database_InsertItems_Handler,
+		},
+		{
+			MethodName: "InsertStatsBatch",
+			Handler:    _Database_InsertStatsBatch_Handler,
+		},
 		{
 			MethodName: "HasItem",
 			Handler:    _Database_HasItem_Handler,
@@ -669,10 +1433,26 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMod",
 			Handler:    _Database_GetMod_Handler,
 		},
+		{
+			MethodName: "SearchItems",
+			Handler:    _Database_SearchItems_Handler,
+		},
 		{
 			MethodName: "GetItemsByCategory",
 			Handler:    _Database_GetItemsByCategory_Handler,
 		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _Database_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "GetPriceCandles",
+			Handler:    _Database_GetPriceCandles_Handler,
+		},
+		{
+			MethodName: "GetLatestPrices",
+			Handler:    _Database_GetLatestPrices_Handler,
+		},
 		{
 			MethodName: "UpdateItemInfo",
 			Handler:    _Database_UpdateItemInfo_Handler,
@@ -681,11 +1461,67 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateNextRun",
 			Handler:    _Database_UpdateNextRun_Handler,
 		},
+		{
+			MethodName: "ExtendLease",
+			Handler:    _Database_ExtendLease_Handler,
+		},
+		{
+			MethodName: "CompleteQuery",
+			Handler:    _Database_CompleteQuery_Handler,
+		},
+		{
+			MethodName: "FailQuery",
+			Handler:    _Database_FailQuery_Handler,
+		},
+		{
+			MethodName: "ListDeadQueries",
+			Handler:    _Database_ListDeadQueries_Handler,
+		},
+		{
+			MethodName: "RequeueQuery",
+			Handler:    _Database_RequeueQuery_Handler,
+		},
+		{
+			MethodName: "ListDuplicateQueries",
+			Handler:    _Database_ListDuplicateQueries_Handler,
+		},
+		{
+			MethodName: "ListQueries",
+			Handler:    _Database_ListQueries_Handler,
+		},
+		{
+			MethodName: "PauseQuery",
+			Handler:    _Database_PauseQuery_Handler,
+		},
+		{
+			MethodName: "ResumeQuery",
+			Handler:    _Database_ResumeQuery_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Database_ListAuditEvents_Handler,
+		},
 		{
 			MethodName: "DeleteQuery",
 			Handler:    _Database_DeleteQuery_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPrices",
+			Handler:       _Database_StreamPrices_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchQueries",
+			Handler:       _Database_WatchQueries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamItemsByCategory",
+			Handler:       _Database_StreamItemsByCategory_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/rdpc.proto",
 }
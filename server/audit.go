@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// auditedPrefixes selects the RPCs recorded in audit_events by the start of
+// their method name.
+var auditedPrefixes = []string{"Insert", "Update", "Upsert", "Delete", "Pause", "Resume", "Requeue"}
+
+// auditKeyFields are the request fields copied into an audit event. Repeated
+// message fields are recorded as their length instead.
+var auditKeyFields = map[protoreflect.Name]bool{
+	"id":               true,
+	"item_id":          true,
+	"realm":            true,
+	"league":           true,
+	"name":             true,
+	"base_type":        true,
+	"currency_id":      true,
+	"base_currency_id": true,
+	"timestamp":        true,
+}
+
+func audited(method string) bool {
+	for _, p := range auditedPrefixes {
+		if strings.HasPrefix(method, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// auditFields extracts the key fields of a request. The id of the row an
+// insert created is taken from the response.
+func auditFields(req, resp any) map[string]string {
+	fields := map[string]string{}
+
+	m, ok := req.(proto.Message)
+	if !ok {
+		return fields
+	}
+
+	m.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			fields[string(fd.Name())] = fmt.Sprint(v.List().Len())
+		case fd.IsList() || fd.IsMap():
+		case auditKeyFields[fd.Name()]:
+			fields[string(fd.Name())] = v.String()
+		}
+
+		return true
+	})
+
+	if r, ok := resp.(proto.Message); ok && fields["id"] == "" {
+		msg := r.ProtoReflect()
+		if fd := msg.Descriptor().Fields().ByName("id"); fd != nil && msg.Has(fd) {
+			fields["id"] = msg.Get(fd).String()
+		}
+	}
+
+	return fields
+}
+
+// AuditUnary records every audited call, whether or not it succeeded or was
+// allowed by the auth policy, with the identity of the client certificate that
+// made it. A failure to write the event is logged and does not fail the call.
+func (s *service) AuditUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	if !audited(method) {
+		return handler(ctx, req)
+	}
+
+	resp, err := handler(ctx, req)
+	s.recordAudit(ctx, method, auditFields(req, resp), err)
+
+	return resp, err
+}
+
+// auditStream counts the messages a client stream received and keeps the
+// message sent back.
+type auditStream struct {
+	grpc.ServerStream
+	received int
+	summary  any
+}
+
+func (as *auditStream) RecvMsg(m any) error {
+	err := as.ServerStream.RecvMsg(m)
+	if err == nil {
+		as.received++
+	}
+
+	return err
+}
+
+func (as *auditStream) SendMsg(m any) error {
+	as.summary = m
+
+	return as.ServerStream.SendMsg(m)
+}
+
+// AuditStream records audited streaming calls. Client streams upload rows, so
+// all of them are audited, with the number of messages received and the
+// accepted and rejected counts of their summary.
+func (s *service) AuditStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	if !info.IsClientStream && !audited(method) {
+		return handler(srv, ss)
+	}
+
+	as := &auditStream{ServerStream: ss}
+
+	err := handler(srv, as)
+
+	fields := map[string]string{"messages": fmt.Sprint(as.received)}
+
+	if summary, ok := as.summary.(*pb.StreamSummary); ok {
+		fields["accepted"] = fmt.Sprint(summary.Accepted)
+		fields["rejected"] = fmt.Sprint(summary.Rejected)
+	}
+
+	s.recordAudit(ss.Context(), method, fields, err)
+
+	return err
+}
+
+// recordAudit writes an audit event for a call that returned err. A failure to
+// write it is only logged.
+func (s *service) recordAudit(ctx context.Context, method string, fields map[string]string, err error) {
+	var caller string
+	if cert := peerCertificate(ctx); cert != nil {
+		caller = strings.Join(certNames(cert), ",")
+	}
+
+	data, _ := json.Marshal(fields)
+
+	query := `
+	INSERT INTO audit_events (timestamp, caller, method, fields, code)
+	VALUES (?, ?, ?, ?, ?)`
+
+	_, auditErr := s.db.Exec(query, time.Now().Unix(), caller, method, string(data), status.Code(err).String())
+	if auditErr != nil {
+		slog.Error("recording audit event", "method", method, "caller", caller, "error", auditErr)
+	}
+}
+
+// ListAuditEvents pages through the audit log, newest first. Caller matches
+// any one of the names the certificate was recorded under, and key matches
+// events where any key field has that value.
+func (s *service) ListAuditEvents(ctx context.Context, ar *pb.ListAuditEventsRequest) (*pb.AuditEvents, error) {
+	cursor, err := decodePageToken(ar.PageToken, 1)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	beforeID := int64(math.MaxInt64)
+	if cursor != nil {
+		beforeID = cursor[0]
+	}
+
+	to := ar.To
+	if to == 0 {
+		to = math.MaxInt64
+	}
+
+	query := `
+	SELECT id, timestamp, caller, method, fields, code
+	FROM audit_events
+	WHERE (? = '' OR method = ?)
+		AND (? = '' OR ',' || caller || ',' LIKE ? ESCAPE '\')
+		AND (? = '' OR EXISTS (SELECT 1 FROM json_each(fields) WHERE value = ?))
+		AND timestamp >= ? AND timestamp < ?
+		AND id < ?
+	ORDER BY id DESC
+	LIMIT ?`
+
+	caller := "%," + likeEscaper.Replace(ar.Caller) + ",%"
+	limit := pageSize(ar.PageSize)
+
+	rows, err := s.db.Query(query,
+		ar.Method, ar.Method,
+		ar.Caller, caller,
+		ar.Key, ar.Key,
+		ar.From, to,
+		beforeID, limit+1,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving audit events: %s", err.Error())
+	}
+	defer rows.Close()
+
+	events := &pb.AuditEvents{}
+
+	for rows.Next() {
+		var e pb.AuditEvent
+		var fields string
+
+		if err := rows.Scan(&e.Id, &e.Timestamp, &e.Caller, &e.Method, &fields, &e.Code); err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning AuditEvent: %s", err.Error())
+		}
+
+		if err := json.Unmarshal([]byte(fields), &e.Fields); err != nil {
+			return nil, status.Errorf(codes.Internal, "decoding AuditEvent %d fields: %s", e.Id, err.Error())
+		}
+
+		events.Events = append(events.Events, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	if len(events.Events) > limit {
+		events.Events = events.Events[:limit]
+		events.NextPageToken = encodePageToken(int64(events.Events[limit-1].Id))
+	}
+
+	return events, nil
+}
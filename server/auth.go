@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// policy maps client certificate identities to the RPCs they may call. It is
+// read from a JSON file of the form
+//
+//	{
+//	  "roles": {
+//	    "scraper": ["Insert*", "Get*Queries", "ExtendLease", "CompleteQuery", "FailQuery"],
+//	    "dashboard": [
+//	      "GetBaseItems", "GetItemsByCategory", "StreamItemsByCategory", "GetPriceHistory",
+//	      "GetPriceCandles", "GetLatestPrices", "GetMod", "Has*", "List*", "SearchItems"
+//	    ]
+//	  },
+//	  "identities": {
+//	    "*.scrapers.internal": ["scraper"],
+//	    "dashboard": ["dashboard"]
+//	  }
+//	}
+//
+// Identities are matched against the certificate's CN and DNS, email and URI
+// SANs, methods against the RPC name without its service prefix. Both use
+// path.Match patterns. Note that GetInfoQueries and GetPriceQueries lease
+// queries, so a read-only role should not be given Get*.
+type policy struct {
+	Roles      map[string][]string `json:"roles"`
+	Identities map[string][]string `json:"identities"`
+}
+
+func loadPolicy(name string) (*policy, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("reading auth policy: %w", err)
+	}
+
+	var p policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing auth policy: %w", err)
+	}
+
+	for role, methods := range p.Roles {
+		for _, m := range methods {
+			if _, err := path.Match(m, ""); err != nil {
+				return nil, fmt.Errorf("role %s: invalid method pattern %q", role, m)
+			}
+		}
+	}
+
+	for id, roles := range p.Identities {
+		if _, err := path.Match(id, ""); err != nil {
+			return nil, fmt.Errorf("invalid identity pattern %q", id)
+		}
+
+		for _, role := range roles {
+			if _, ok := p.Roles[role]; !ok {
+				return nil, fmt.Errorf("identity %s: unknown role %q", id, role)
+			}
+		}
+	}
+
+	return &p, nil
+}
+
+// allows reports whether any of names holds a role that may call method.
+func (p *policy) allows(names []string, method string) bool {
+	method = method[strings.LastIndex(method, "/")+1:]
+
+	for id, roles := range p.Identities {
+		if !matchAny(id, names) {
+			continue
+		}
+
+		for _, role := range roles {
+			for _, m := range p.Roles[role] {
+				if ok, _ := path.Match(m, method); ok {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func matchAny(pattern string, names []string) bool {
+	for _, n := range names {
+		if ok, _ := path.Match(pattern, n); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// peerCertificate returns the verified client certificate of the call, or nil
+// when the connection did not present one.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return info.State.VerifiedChains[0][0]
+}
+
+// certNames lists the identities a certificate can be matched by: its CN
+// followed by its SANs.
+func certNames(cert *x509.Certificate) []string {
+	var names []string
+
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+
+	names = append(names, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+
+	for _, u := range cert.URIs {
+		names = append(names, u.String())
+	}
+
+	return names
+}
+
+func (p *policy) authorize(ctx context.Context, method string) error {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return status.Error(codes.Unauthenticated, "no verified client certificate")
+	}
+
+	names := certNames(cert)
+	if !p.allows(names, method) {
+		return status.Errorf(codes.PermissionDenied, "%s may not call %s", strings.Join(names, ","), method)
+	}
+
+	return nil
+}
+
+func (p *policy) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := p.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (p *policy) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := p.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// execBatch runs query once per row inside a single transaction. A failing row
+// is reported in its RowResult and does not prevent the others from being
+// committed.
+func (s *service) execBatch(query string, n int, args func(i int) []any) (*pb.BatchResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "beginning batch: %s", err.Error())
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "preparing batch: %s", err.Error())
+	}
+	defer stmt.Close()
+
+	result := &pb.BatchResult{Results: make([]*pb.RowResult, n)}
+
+	for i := range n {
+		row := &pb.RowResult{Index: uint32(i), Ok: true}
+
+		if _, err := stmt.Exec(args(i)...); err != nil {
+			row.Ok = false
+			row.Error = err.Error()
+			result.Failed++
+		} else {
+			result.Inserted++
+		}
+
+		result.Results[i] = row
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "committing batch: %s", err.Error())
+	}
+
+	return result, nil
+}
+
+func (s *service) InsertPrices(ctx context.Context, ps *pb.Prices) (*pb.BatchResult, error) {
+	return s.execBatch(insertPriceQuery, len(ps.Prices), func(i int) []any {
+		p := ps.Prices[i]
+		return []any{p.ItemId, p.Price, p.CurrencyId, p.Volume, p.Stock, p.League, p.Timestamp}
+	})
+}
+
+func (s *service) InsertItems(ctx context.Context, is *pb.Items) (*pb.BatchResult, error) {
+	return s.execBatch(insertItemQuery, len(is.Items), func(i int) []any {
+		it := is.Items[i]
+		return []any{it.Name, it.BaseType, it.Category, it.SubCategory, it.Realm}
+	})
+}
+
+func (s *service) InsertStatsBatch(ctx context.Context, sb *pb.StatsBatch) (*pb.BatchResult, error) {
+	return s.execBatch(insertStatsQuery, len(sb.Stats), func(i int) []any {
+		st := sb.Stats[i]
+		return []any{st.Id, st.Text, st.Type}
+	})
+}
+
+// StreamPrices buffers incoming prices and writes them with InsertPrices every
+// priceChunkSize rows, so no transaction is held open while waiting on the
+// client.
+func (s *service) StreamPrices(stream pb.Database_StreamPricesServer) error {
+	summary := &pb.StreamSummary{}
+	chunk := make([]*pb.Price, 0, s.priceChunkSize)
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		result, err := s.InsertPrices(stream.Context(), &pb.Prices{Prices: chunk})
+		if err != nil {
+			return err
+		}
+
+		summary.Accepted += uint64(result.Inserted)
+		summary.Rejected += uint64(result.Failed)
+
+		if summary.FirstError == "" {
+			for _, r := range result.Results {
+				if !r.Ok {
+					summary.FirstError = r.Error
+					break
+				}
+			}
+		}
+
+		chunk = chunk[:0]
+
+		return nil
+	}
+
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		chunk = append(chunk, p)
+
+		if len(chunk) >= s.priceChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(summary)
+}
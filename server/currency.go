@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// convertedPricesCTE exposes the prices table as converted_prices, with price
+// and currency_id expressed in a base currency using the rate nearest to each
+// row's timestamp. The stored currency is kept as source_currency_id. When the
+// base currency is empty prices pass through unchanged; rows with no direct or
+// inverse rate get a NULL price. Bind it with convertedPricesArgs.
+//
+// Each lookup reads the last rate at or before the price and the first at or
+// after it, both by seeking idx_currency_rates_pair, and keeps the closer one
+// (the MIN picks which row the bare rate column comes from).
+const convertedPricesCTE = `
+	converted_prices AS (
+		SELECT
+			p.id,
+			p.item_id,
+			CASE
+				WHEN ? = '' OR p.currency_id = ? THEN p.price
+				ELSE p.price * COALESCE(
+					(
+						SELECT rate FROM (
+							SELECT rate, MIN(ABS(ts - p.timestamp)) FROM (
+								SELECT * FROM (
+									SELECT r.rate AS rate, r.timestamp AS ts
+									FROM currency_rates r
+									WHERE r.league = p.league AND r.currency_id = p.currency_id AND r.base_currency_id = ?
+										AND r.timestamp <= p.timestamp
+									ORDER BY r.timestamp DESC
+									LIMIT 1
+								)
+								UNION ALL
+								SELECT * FROM (
+									SELECT r.rate AS rate, r.timestamp AS ts
+									FROM currency_rates r
+									WHERE r.league = p.league AND r.currency_id = p.currency_id AND r.base_currency_id = ?
+										AND r.timestamp >= p.timestamp
+									ORDER BY r.timestamp
+									LIMIT 1
+								)
+							)
+						)
+					),
+					(
+						SELECT rate FROM (
+							SELECT rate, MIN(ABS(ts - p.timestamp)) FROM (
+								SELECT * FROM (
+									SELECT 1.0 / r.rate AS rate, r.timestamp AS ts
+									FROM currency_rates r
+									WHERE r.league = p.league AND r.currency_id = ? AND r.base_currency_id = p.currency_id
+										AND r.timestamp <= p.timestamp
+									ORDER BY r.timestamp DESC
+									LIMIT 1
+								)
+								UNION ALL
+								SELECT * FROM (
+									SELECT 1.0 / r.rate AS rate, r.timestamp AS ts
+									FROM currency_rates r
+									WHERE r.league = p.league AND r.currency_id = ? AND r.base_currency_id = p.currency_id
+										AND r.timestamp >= p.timestamp
+									ORDER BY r.timestamp
+									LIMIT 1
+								)
+							)
+						)
+					)
+				)
+			END AS price,
+			CASE WHEN ? = '' THEN p.currency_id ELSE ? END AS currency_id,
+			p.currency_id AS source_currency_id,
+			p.volume,
+			p.stock,
+			p.league,
+			p.timestamp
+		FROM prices p
+	)`
+
+func convertedPricesArgs(base string) []any {
+	return []any{base, base, base, base, base, base, base, base}
+}
+
+func (s *service) InsertCurrencyRate(ctx context.Context, r *pb.CurrencyRate) (*pb.Empty, error) {
+	if r.League == "" || r.CurrencyId == "" || r.BaseCurrencyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "league, currency_id and base_currency_id are required")
+	}
+
+	if r.Rate <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "rate must be positive: %v", r.Rate)
+	}
+
+	query := `
+	INSERT INTO currency_rates (league, currency_id, base_currency_id, rate, timestamp)
+	VALUES (?, ?, ?, ?, ?)`
+
+	_, err := s.db.Exec(query, r.League, r.CurrencyId, r.BaseCurrencyId, r.Rate, r.Timestamp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "inserting currency rate for CurrencyId: %s: %s", r.CurrencyId, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
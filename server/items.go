@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// itemColumns is the select list read by scanItem.
+const itemColumns = `
+		id,
+		realm,
+		category,
+		sub_category,
+		icon,
+		icon_tier_text,
+		name,
+		base_type,
+		rarity,
+		w,
+		h,
+		ilvl,
+		socketed_items,
+		properties,
+		requirements,
+		enchant_mods,
+		rune_mods,
+		implicit_mods,
+		explicit_mods,
+		fractured_mods,
+		desecrated_mods,
+		flavour_text,
+		descr_text,
+		sec_descr_text,
+		support,
+		duplicated,
+		corrupted,
+		sanctified,
+		desecrated`
+
+// itemFilter narrows items by the optional CategoryRequest filters and resumes
+// after the page token's id. Bind it with itemFilterArgs.
+const itemFilter = `
+	(? = '' OR realm = ?)
+	AND (? = '' OR sub_category = ?)
+	AND (? = '' OR rarity = ?)
+	AND (? = '' OR name LIKE ? ESCAPE '\')
+	AND (? IS NULL OR corrupted = ?)
+	AND (? IS NULL OR sanctified = ?)
+	AND (? IS NULL OR duplicated = ?)
+	AND (? IS NULL OR desecrated = ?)
+	AND id > ?`
+
+func itemFilterArgs(cr *pb.CategoryRequest, afterID int64) []any {
+	prefix := ""
+	if cr.NamePrefix != "" {
+		prefix = likeEscaper.Replace(cr.NamePrefix) + "%"
+	}
+
+	return []any{
+		cr.Realm, cr.Realm,
+		cr.SubCategory, cr.SubCategory,
+		cr.Rarity, cr.Rarity,
+		prefix, prefix,
+		cr.Corrupted, cr.Corrupted,
+		cr.Sanctified, cr.Sanctified,
+		cr.Duplicated, cr.Duplicated,
+		cr.Desecrated, cr.Desecrated,
+		afterID,
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// itemPage decodes the paging fields of a CategoryRequest. A zero page size
+// keeps the old unpaged behaviour.
+func itemPage(cr *pb.CategoryRequest) (afterID int64, limit int, err error) {
+	cursor, err := decodePageToken(cr.PageToken, 1)
+	if err != nil {
+		return 0, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if cursor != nil {
+		afterID = cursor[0]
+	}
+
+	if cr.PageSize == 0 {
+		return afterID, math.MaxInt32, nil
+	}
+
+	return afterID, pageSize(cr.PageSize), nil
+}
+
+func itemPageToken(lastID string) string {
+	id, err := strconv.ParseInt(lastID, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	return encodePageToken(id)
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanItem(r rowScanner) (*pb.Item, error) {
+	var i pb.Item
+
+	err := r.Scan(
+		&i.Id,
+		&i.Realm,
+		&i.Category,
+		&i.SubCategory,
+		&i.Icon,
+		&i.IconTierText,
+		&i.Name,
+		&i.BaseType,
+		&i.Rarity,
+		&i.W,
+		&i.H,
+		&i.Ilvl,
+		&i.SocketedItems,
+		&i.Properties,
+		&i.Requirements,
+		&i.EnchantMods,
+		&i.RuneMods,
+		&i.ImplicitMods,
+		&i.ExplicitMods,
+		&i.FracturedMods,
+		&i.DesecratedMods,
+		&i.FlavourText,
+		&i.DescrText,
+		&i.SecDescrText,
+		&i.Support,
+		&i.Duplicated,
+		&i.Corrupted,
+		&i.Sanctified,
+		&i.Desecrated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// itemStreamChunk is how many rows StreamItemsByCategory reads per query.
+const itemStreamChunk = 500
+
+// StreamItemsByCategory sends items in chunks so large categories are not
+// limited by the maximum message size. Each chunk is read and its rows closed
+// before it is sent, so a slow client does not hold the database connection.
+func (s *service) StreamItemsByCategory(c *pb.CategoryRequest, stream pb.Database_StreamItemsByCategoryServer) error {
+	afterID, limit, err := itemPage(c)
+	if err != nil {
+		return err
+	}
+
+	for limit > 0 {
+		items, err := s.itemChunk(stream.Context(), c, afterID, min(limit, itemStreamChunk))
+		if err != nil {
+			return err
+		}
+
+		for _, i := range items {
+			if err := stream.Send(i); err != nil {
+				return err
+			}
+		}
+
+		if len(items) < min(limit, itemStreamChunk) {
+			return nil
+		}
+
+		limit -= len(items)
+
+		afterID, err = strconv.ParseInt(items[len(items)-1].Id, 10, 64)
+		if err != nil {
+			return status.Errorf(codes.Internal, "parsing Item id: %s: %s", items[len(items)-1].Id, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// itemChunk reads up to n items of the category after afterID.
+func (s *service) itemChunk(ctx context.Context, c *pb.CategoryRequest, afterID int64, n int) ([]*pb.Item, error) {
+	query := `
+	SELECT` + itemColumns + `
+	FROM items
+	WHERE category = ? AND` + itemFilter + `
+	ORDER BY id
+	LIMIT ?`
+
+	args := append([]any{c.Category}, itemFilterArgs(c, afterID)...)
+
+	rows, err := s.db.QueryContext(ctx, query, append(args, n)...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving Items: %s: %s", c.Category, err.Error())
+	}
+	defer rows.Close()
+
+	var items []*pb.Item
+
+	for rows.Next() {
+		i, err := scanItem(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning Item: %s: %s", c.Category, err.Error())
+		}
+
+		items = append(items, i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	return items, nil
+}
@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -26,7 +27,15 @@ import (
 
 type service struct {
 	pb.UnimplementedDatabaseServer
-	db *sql.DB
+	db             *sql.DB
+	priceChunkSize int
+	maxAttempts    int
+	maxJitter      time.Duration
+	queue          *notifier
+	limiter        *limiter
+	// shutdown is closed when the server starts stopping, ending long-lived
+	// streams so GracefulStop does not wait on them.
+	shutdown <-chan struct{}
 }
 
 func main() {
@@ -40,19 +49,71 @@ func run() error {
 	port := os.Getenv("GRPC_PORT")
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	tlsConfig, err := createTLSConfig()
+	denied, err := parseSerials(os.Getenv("TLS_REVOKED_SERIALS"))
 	if err != nil {
 		return err
 	}
 
+	certs, err := newTLSReloader(os.Getenv("TLS_CERT"), os.Getenv("TLS_KEY"), os.Getenv("TLS_CA"), os.Getenv("TLS_CRL"), denied)
+	if err != nil {
+		return err
+	}
+
+	go certs.watch(ctx, time.Duration(envInt("TLS_RELOAD_INTERVAL_SECONDS", 60))*time.Second)
+
 	db, err := initDB()
 	if err != nil {
 		return err
 	}
 
-	creds := credentials.NewTLS(tlsConfig)
-	grpcSrv := grpc.NewServer(grpc.Creds(creds), grpc.ChainUnaryInterceptor(SlogUnary))
-	pb.RegisterDatabaseServer(grpcSrv, &service{db: db})
+	limiter, err := parseRateLimits(os.Getenv("REALM_RATE_LIMITS"), time.Now())
+	if err != nil {
+		return err
+	}
+
+	svc := &service{
+		db:             db,
+		priceChunkSize: envInt("PRICE_STREAM_CHUNK_SIZE", 500),
+		maxAttempts:    envInt("QUERY_MAX_ATTEMPTS", 5),
+		maxJitter:      time.Duration(envIntMin("QUERY_MAX_JITTER_SECONDS", 60, 0)) * time.Second,
+		queue:          newNotifier(),
+		limiter:        limiter,
+		shutdown:       ctx.Done(),
+	}
+
+	go svc.sweepLeases(ctx, time.Duration(envInt("LEASE_SWEEP_INTERVAL_SECONDS", 30))*time.Second)
+
+	// Auditing runs ahead of the policy so denied calls are recorded too.
+	unary := []grpc.UnaryServerInterceptor{SlogUnary, MetricsUnary, svc.AuditUnary}
+	stream := []grpc.StreamServerInterceptor{SlogStream, MetricsStream, svc.AuditStream}
+
+	if name := os.Getenv("AUTH_POLICY"); name != "" {
+		policy, err := loadPolicy(name)
+		if err != nil {
+			return err
+		}
+
+		unary = append(unary, policy.Unary)
+		stream = append(stream, policy.Stream)
+	} else {
+		slog.Warn("AUTH_POLICY not set, any client certificate may call every method")
+	}
+
+	creds := credentials.NewTLS(certs.serverConfig())
+	grpcSrv := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	pb.RegisterDatabaseServer(grpcSrv, svc)
+
+	if metricsPort := os.Getenv("METRICS_PORT"); metricsPort != "" {
+		go func() {
+			if err := serveMetrics(ctx, metricsPort, db); err != nil {
+				slog.Error("serving metrics", "error", err)
+			}
+		}()
+	}
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
@@ -76,11 +137,7 @@ func run() error {
 	return nil
 }
 
-func createTLSConfig() (*tls.Config, error) {
-	tlsCertDir := os.Getenv("TLS_CERT")
-	tlsKeyDir := os.Getenv("TLS_KEY")
-	tlsCaDir := os.Getenv("TLS_CA")
-
+func createTLSConfig(tlsCertDir, tlsKeyDir, tlsCaDir, tlsCrlDir string, denied map[string]bool) (*tls.Config, error) {
 	cert, err := tls.LoadX509KeyPair(tlsCertDir, tlsKeyDir)
 	if err != nil {
 		return nil, fmt.Errorf("loading server certificates: %w", err)
@@ -93,14 +150,25 @@ func createTLSConfig() (*tls.Config, error) {
 
 	certPool := x509.NewCertPool()
 	if !certPool.AppendCertsFromPEM(ca) {
-		return nil, fmt.Errorf("adding CA certificate to pool: %w", err)
+		return nil, fmt.Errorf("adding CA certificate to pool: no certificates in %s", tlsCaDir)
 	}
 
-	return &tls.Config{
+	config := &tls.Config{
 		ClientAuth:   tls.RequireAndVerifyClientCert,
 		Certificates: []tls.Certificate{cert},
 		ClientCAs:    certPool,
-	}, nil
+	}
+
+	if tlsCrlDir != "" || len(denied) > 0 {
+		revocations, err := loadRevocations(tlsCrlDir, ca, denied)
+		if err != nil {
+			return nil, err
+		}
+
+		config.VerifyConnection = revocations.verify
+	}
+
+	return config, nil
 }
 
 func initDB() (*sql.DB, error) {
@@ -116,6 +184,11 @@ func initDB() (*sql.DB, error) {
 	db.Exec("PRAGMA journal_mode=WAL;")
 	db.Exec("PRAGMA busy_timeout=5000;")
 
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating db: %w", err)
+	}
+
 	return db, nil
 }
 
@@ -128,15 +201,46 @@ func SlogUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler
 	return resp, err
 }
 
-func (s *service) InsertStats(ctx context.Context, st *pb.Stats) (*pb.Empty, error) {
-	query := `
+func SlogStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+	slog.Info("grpc", "method", info.FullMethod, "duration", time.Since(start))
+
+	return err
+}
+
+// envInt reads a positive integer from the environment, falling back to def
+// when the variable is unset or invalid.
+func envInt(name string, def int) int {
+	return envIntMin(name, def, 1)
+}
+
+// envIntMin reads an integer setting that may not be below least.
+func envIntMin(name string, def, least int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < least {
+		slog.Warn("invalid env value, using default", "name", name, "value", v, "default", def)
+		return def
+	}
+
+	return n
+}
+
+const insertStatsQuery = `
 	INSERT INTO stats (id, text, type)
 	VALUES (?, ?, ?)
 	ON CONFLICT(id) DO UPDATE SET
 		text = excluded.text,
 		type = excluded.type`
 
-	_, err := s.db.Exec(query, st.Id, st.Text, st.Type)
+func (s *service) InsertStats(ctx context.Context, st *pb.Stats) (*pb.Empty, error) {
+	_, err := s.db.Exec(insertStatsQuery, st.Id, st.Text, st.Type)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "inserting stats for Id: %s: %s", st.Id, err.Error())
 	}
@@ -144,56 +248,48 @@ func (s *service) InsertStats(ctx context.Context, st *pb.Stats) (*pb.Empty, err
 	return &pb.Empty{}, nil
 }
 
-func (s *service) InsertItem(ctx context.Context, i *pb.Item) (*pb.Empty, error) {
-	query := `
+const insertItemQuery = `
 	INSERT INTO items (name, base_type, category, sub_category, realm)
 	VALUES (?, ?, ?, ?, ?)`
 
-	_, err := s.db.Exec(query, i.Name, i.BaseType, i.Category, i.SubCategory, i.Realm)
+func (s *service) InsertItem(ctx context.Context, i *pb.Item) (*pb.ItemResult, error) {
+	item, err := scanItem(s.db.QueryRow(insertItemQuery+` RETURNING`+itemColumns, i.Name, i.BaseType, i.Category, i.SubCategory, i.Realm))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "inserting item: %s", err.Error())
 	}
 
-	return &pb.Empty{}, nil
+	return &pb.ItemResult{Id: item.Id, Item: item, Created: true}, nil
 }
 
-func (s *service) InsertItemWithID(ctx context.Context, i *pb.Item) (*pb.Empty, error) {
+func (s *service) InsertItemWithID(ctx context.Context, i *pb.Item) (*pb.ItemResult, error) {
 	query := `
 	INSERT INTO items (id, name, base_type, category, sub_category, realm)
-	VALUES (?, ?, ?, ?, ?, ?)`
+	VALUES (?, ?, ?, ?, ?, ?)
+	RETURNING` + itemColumns
 
-	_, err := s.db.Exec(query, i.Id, i.Name, i.BaseType, i.Category, i.SubCategory, i.Realm)
+	item, err := scanItem(s.db.QueryRow(query, i.Id, i.Name, i.BaseType, i.Category, i.SubCategory, i.Realm))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "inserting item with Id: %s: %s", i.Id, err.Error())
 	}
 
-	return &pb.Empty{}, nil
+	return &pb.ItemResult{Id: item.Id, Item: item, Created: true}, nil
 }
 
-func (s *service) InsertQuery(ctx context.Context, q *pb.Query) (*pb.Empty, error) {
-	query := `
-	INSERT INTO queries (item_id, realm, league, search_query, update_interval, next_run, run_once)
-	VALUES (?, ?, ?, ?, ?, ?, ?)`
-
-	_, err := s.db.Exec(query, q.ItemId, q.Realm, q.League, q.Query, q.Update, q.NextRun, q.RunOnce)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "inserting query for ItemId: %s: %s", q.ItemId, err.Error())
-	}
-
-	return &pb.Empty{}, nil
+func (s *service) InsertQuery(ctx context.Context, q *pb.Query) (*pb.QueryResult, error) {
+	return s.upsertQuery(q)
 }
 
-func (s *service) InsertPrice(ctx context.Context, p *pb.Price) (*pb.Empty, error) {
-	query := `
-		INSERT INTO prices (item_id, price, currency_id, volume, stock, league, timestamp)
-		VALUES (?, ?, ?, ?, ?, ?, ?)`
+const insertPriceQuery = `
+	INSERT INTO prices (item_id, price, currency_id, volume, stock, league, timestamp)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := s.db.Exec(query, p.ItemId, p.Price, p.CurrencyId, p.Volume, p.Stock, p.League, p.Timestamp)
+func (s *service) InsertPrice(ctx context.Context, p *pb.Price) (*pb.PriceResult, error) {
+	price, err := scanPrice(s.db.QueryRow(insertPriceQuery+` RETURNING`+priceColumns, p.ItemId, p.Price, p.CurrencyId, p.Volume, p.Stock, p.League, p.Timestamp))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "inserting price for ItemId: %s: %s", p.ItemId, err.Error())
 	}
 
-	return &pb.Empty{}, nil
+	return &pb.PriceResult{Id: price.Id, Price: price, Created: true}, nil
 }
 
 func (s *service) HasItem(ctx context.Context, ir *pb.HasItemRequest) (*pb.BoolResponse, error) {
@@ -262,6 +358,11 @@ func (s *service) HasPriceQuery(ctx context.Context, pr *pb.HasPriceRequest) (*p
 }
 
 func (s *service) GetBaseItems(ctx context.Context, cr *pb.CategoryRequest) (*pb.BaseItems, error) {
+	afterID, limit, err := itemPage(cr)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 	SELECT
 		id,
@@ -269,9 +370,13 @@ func (s *service) GetBaseItems(ctx context.Context, cr *pb.CategoryRequest) (*pb
 		name,
 		base_type
 	FROM items
-	WHERE (? = '' OR category = ?)`
+	WHERE (? = '' OR category = ?) AND` + itemFilter + `
+	ORDER BY id
+	LIMIT ?`
 
-	rows, err := s.db.Query(query, cr.Category, cr.Category)
+	args := append([]any{cr.Category, cr.Category}, itemFilterArgs(cr, afterID)...)
+
+	rows, err := s.db.Query(query, append(args, limit+1)...)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "retrieving BaseItems: %s: %s", cr.Category, err.Error())
 	}
@@ -299,91 +404,20 @@ func (s *service) GetBaseItems(ctx context.Context, cr *pb.CategoryRequest) (*pb
 		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
 	}
 
-	return items, nil
-}
-
-func (s *service) GetInfoQueries(ctx context.Context, _ *pb.Empty) (*pb.Queries, error) {
-	query := `
-	UPDATE queries
-	SET status = 'in_progress', started_at = ?
-	WHERE id IN (
-		SELECT id
-		FROM queries
-		WHERE (status = 'queued' OR (status = 'in_progress' AND started_at < ?)) AND next_run < ? AND run_once = true
-		ORDER BY id
-		LIMIT 4
-	)
-	RETURNING id, item_id, realm, league, search_query, update_interval, next_run, status, started_at, run_once`
-
-	now := time.Now().UTC().Unix()
-	lease := time.Now().Add(-5 * time.Minute).UTC().Unix()
-
-	rows, err := s.db.Query(query, now, lease, now)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "retrieving InfoQueries: %s", err.Error())
-	}
-	defer rows.Close()
-
-	queries := &pb.Queries{}
-
-	for rows.Next() {
-		var q pb.Query
-
-		err := rows.Scan(&q.Id, &q.ItemId, &q.Realm, &q.League, &q.Query, &q.Update, &q.NextRun, &q.Status, &q.StartedAt, &q.RunOnce)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "scaning info Query: %s", err.Error())
-		}
-
-		queries.Queries = append(queries.Queries, &q)
+	if len(items.Items) > limit {
+		items.Items = items.Items[:limit]
+		items.NextPageToken = itemPageToken(items.Items[limit-1].Id)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
-	}
-
-	return queries, nil
+	return items, nil
 }
 
-func (s *service) GetPriceQueries(ctx context.Context, _ *pb.Empty) (*pb.Queries, error) {
-	query := `
-	UPDATE queries
-	SET status = 'in_progress', started_at = ?
-	WHERE id IN (
-		SELECT id
-		FROM queries
-		WHERE (status = 'queued' OR (status = 'in_progress' AND started_at < ?)) AND next_run < ? AND run_once = false
-		ORDER BY id
-		LIMIT 4
-	)
-	RETURNING id, item_id, realm, league, search_query, update_interval, next_run, status, started_at, run_once`
-
-	now := time.Now().UTC().Unix()
-	lease := time.Now().Add(-5 * time.Minute).UTC().Unix()
-
-	rows, err := s.db.Query(query, now, lease, now)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "retrieving PriceQueries: %s", err.Error())
-	}
-	defer rows.Close()
-
-	queries := &pb.Queries{}
-
-	for rows.Next() {
-		var q pb.Query
-
-		err := rows.Scan(&q.Id, &q.ItemId, &q.Realm, &q.League, &q.Query, &q.Update, &q.NextRun, &q.Status, &q.StartedAt, &q.RunOnce)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "scaning PriceQuery: %s:", err.Error())
-		}
-
-		queries.Queries = append(queries.Queries, &q)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
-	}
+func (s *service) GetInfoQueries(ctx context.Context, lr *pb.LeaseRequest) (*pb.Queries, error) {
+	return s.leaseQueries(lr, true)
+}
 
-	return queries, nil
+func (s *service) GetPriceQueries(ctx context.Context, lr *pb.LeaseRequest) (*pb.Queries, error) {
+	return s.leaseQueries(lr, false)
 }
 
 func (s *service) GetMod(ctx context.Context, mr *pb.GetModRequest) (*pb.GetModResponse, error) {
@@ -403,39 +437,21 @@ func (s *service) GetMod(ctx context.Context, mr *pb.GetModRequest) (*pb.GetModR
 }
 
 func (s *service) GetItemsByCategory(context context.Context, c *pb.CategoryRequest) (*pb.Items, error) {
+	afterID, limit, err := itemPage(c)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-	SELECT
-		realm,
-		category,
-		sub_category,
-		icon,
-		icon_tier_text,
-		name,
-		base_type,
-		rarity,
-		w,
-		h,
-		ilvl,
-		socketed_items,
-		properties,
-		requirements,
-		rune_mods,
-		implicit_mods,
-		explicit_mods,
-		fractured_mods,
-		desecrated_mods,
-		flavour_text,
-		descr_text,
-		sec_descr_text,
-		support,
-		duplicated,
-		corrupted,
-		sanctified,
-		desecrated
+	SELECT` + itemColumns + `
 	FROM items
-	WHERE category = ?`
+	WHERE category = ? AND` + itemFilter + `
+	ORDER BY id
+	LIMIT ?`
+
+	args := append([]any{c.Category}, itemFilterArgs(c, afterID)...)
 
-	rows, err := s.db.Query(query, c.Category)
+	rows, err := s.db.Query(query, append(args, limit+1)...)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "retrieving Items: %s: %s", c.Category, err.Error())
 	}
@@ -443,50 +459,26 @@ func (s *service) GetItemsByCategory(context context.Context, c *pb.CategoryRequ
 
 	var items = &pb.Items{}
 	for rows.Next() {
-		var i pb.Item
-
-		err := rows.Scan(
-			&i.Realm,
-			&i.Category,
-			&i.SubCategory,
-			&i.Icon,
-			&i.IconTierText,
-			&i.Name,
-			&i.BaseType,
-			&i.Rarity,
-			&i.W,
-			&i.H,
-			&i.Ilvl,
-			&i.SocketedItems,
-			&i.Properties,
-			&i.Requirements,
-			&i.RuneMods,
-			&i.ImplicitMods,
-			&i.ExplicitMods,
-			&i.FracturedMods,
-			&i.DesecratedMods,
-			&i.FlavourText,
-			&i.DescrText,
-			&i.SecDescrText,
-			&i.Support,
-			&i.Duplicated,
-			&i.Corrupted,
-			&i.Sanctified,
-			&i.Desecrated,
-		)
+		i, err := scanItem(rows)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "scaning Item: %s: %s", c.Category, err.Error())
 		}
 
-		items.Items = append(items.Items, &i)
+		items.Items = append(items.Items, i)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
 	}
 
+	if len(items.Items) > limit {
+		items.Items = items.Items[:limit]
+		items.NextPageToken = itemPageToken(items.Items[limit-1].Id)
+	}
+
 	return items, nil
 }
+
 func (s *service) UpdateItemInfo(ctx context.Context, i *pb.Item) (*pb.Empty, error) {
 	query := `
 	UPDATE items
@@ -530,16 +522,29 @@ func (s *service) UpdateItemInfo(ctx context.Context, i *pb.Item) (*pb.Empty, er
 func (s *service) UpdateNextRun(ctx context.Context, q *pb.Query) (*pb.Empty, error) {
 	query := `
 	UPDATE queries
-	SET next_run = ?, status = 'queued', started_at = 0
+	SET next_run = ?, status = CASE WHEN status = 'paused' THEN status ELSE 'queued' END, started_at = 0, lease_owner = '', lease_expires_at = 0, attempts = 0
 	WHERE id = ? AND league = ?`
 
-	nextRun := time.Now().Add(time.Duration(q.Update) * time.Hour).UTC().Unix()
+	schedule := q.Schedule
+	if schedule == "" {
+		err := s.db.QueryRow(`SELECT schedule FROM queries WHERE id = ?`, q.Id).Scan(&schedule)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, status.Errorf(codes.Internal, "reading schedule: %d: %s", q.Id, err.Error())
+		}
+	}
+
+	next, err := nextRun(schedule, q.Update, time.Now(), s.maxJitter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
-	_, err := s.db.Exec(query, nextRun, q.Id, q.League)
+	_, err = s.db.Exec(query, next, q.Id, q.League)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "updating next run: %d: %s", q.Id, err.Error())
 	}
 
+	s.queue.notify()
+
 	return &pb.Empty{}, nil
 }
 
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rdpc_grpc_requests_total",
+		Help: "gRPC calls handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rdpc_grpc_request_duration_seconds",
+		Help:    "Time spent handling gRPC calls, by method. Streams are timed until they end.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+	}, []string{"method"})
+
+	leaseExpiries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rdpc_lease_expiries_total",
+		Help: "Query leases that ran out before the worker completed or failed them, by the status the query was moved to.",
+	}, []string{"outcome"})
+)
+
+func observe(method string, start time.Time, err error) {
+	grpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	grpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func MetricsUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+	observe(info.FullMethod, start, err)
+
+	return resp, err
+}
+
+func MetricsStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+	observe(info.FullMethod, start, err)
+
+	return err
+}
+
+// queueCollector reports the number of queries by status, run_once and
+// league, counted when scraped.
+type queueCollector struct {
+	db    *sql.DB
+	depth *prometheus.Desc
+}
+
+func newQueueCollector(db *sql.DB) *queueCollector {
+	return &queueCollector{
+		db: db,
+		depth: prometheus.NewDesc(
+			"rdpc_queue_queries",
+			"Queries in the queue, by status, run_once and league.",
+			[]string{"status", "run_once", "league"}, nil,
+		),
+	}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.depth
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	query := `
+	SELECT status, run_once, league, COUNT(*)
+	FROM queries
+	GROUP BY status, run_once, league`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.depth, err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state, league string
+		var runOnce bool
+		var n float64
+
+		if err := rows.Scan(&state, &runOnce, &league, &n); err != nil {
+			ch <- prometheus.NewInvalidMetric(c.depth, err)
+			return
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.depth, prometheus.GaugeValue, n, state, strconv.FormatBool(runOnce), league)
+	}
+
+	if err := rows.Err(); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.depth, err)
+	}
+}
+
+// serveMetrics registers the database collectors and serves /metrics on port
+// until ctx is done.
+func serveMetrics(ctx context.Context, port string, db *sql.DB) error {
+	prometheus.MustRegister(
+		collectors.NewDBStatsCollector(db, "rdpc"),
+		newQueueCollector(db),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{Addr: ":" + port, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+
+	slog.Info("starting metrics server", "port", port)
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+CREATE TABLE IF NOT EXISTS items (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	realm TEXT NOT NULL DEFAULT '',
+	category TEXT NOT NULL DEFAULT '',
+	sub_category TEXT NOT NULL DEFAULT '',
+	icon TEXT NOT NULL DEFAULT '',
+	icon_tier_text TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL DEFAULT '',
+	base_type TEXT NOT NULL DEFAULT '',
+	rarity TEXT NOT NULL DEFAULT '',
+	w INTEGER NOT NULL DEFAULT 0,
+	h INTEGER NOT NULL DEFAULT 0,
+	ilvl INTEGER NOT NULL DEFAULT 0,
+	socketed_items BLOB,
+	properties BLOB,
+	requirements BLOB,
+	enchant_mods BLOB,
+	rune_mods BLOB,
+	implicit_mods BLOB,
+	explicit_mods BLOB,
+	fractured_mods BLOB,
+	desecrated_mods BLOB,
+	flavour_text TEXT NOT NULL DEFAULT '',
+	descr_text TEXT NOT NULL DEFAULT '',
+	sec_descr_text TEXT NOT NULL DEFAULT '',
+	support BOOLEAN NOT NULL DEFAULT false,
+	duplicated BOOLEAN NOT NULL DEFAULT false,
+	corrupted BOOLEAN NOT NULL DEFAULT false,
+	sanctified BOOLEAN NOT NULL DEFAULT false,
+	desecrated BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_category ON items (category);
+CREATE INDEX IF NOT EXISTS idx_items_name_base_type ON items (name, base_type);
+
+CREATE TABLE IF NOT EXISTS stats (
+	id TEXT PRIMARY KEY,
+	text TEXT NOT NULL DEFAULT '',
+	type TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS queries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	item_id TEXT NOT NULL,
+	realm TEXT NOT NULL DEFAULT '',
+	league TEXT NOT NULL DEFAULT '',
+	search_query TEXT NOT NULL DEFAULT '',
+	update_interval INTEGER NOT NULL DEFAULT 0,
+	next_run INTEGER NOT NULL DEFAULT 0,
+	status TEXT NOT NULL DEFAULT 'queued',
+	started_at INTEGER NOT NULL DEFAULT 0,
+	run_once BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS idx_queries_item_id ON queries (item_id, league, run_once);
+CREATE INDEX IF NOT EXISTS idx_queries_status ON queries (status, next_run, run_once);
+
+CREATE TABLE IF NOT EXISTS prices (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	item_id TEXT NOT NULL,
+	price REAL NOT NULL DEFAULT 0,
+	currency_id TEXT NOT NULL DEFAULT '',
+	volume INTEGER NOT NULL DEFAULT 0,
+	stock INTEGER NOT NULL DEFAULT 0,
+	league TEXT NOT NULL DEFAULT '',
+	timestamp INTEGER NOT NULL DEFAULT 0
+);
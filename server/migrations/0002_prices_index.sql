@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_prices_item_league_timestamp ON prices (item_id, league, timestamp);
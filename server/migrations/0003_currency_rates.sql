@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS currency_rates (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	league TEXT NOT NULL,
+	currency_id TEXT NOT NULL,
+	base_currency_id TEXT NOT NULL,
+	rate REAL NOT NULL,
+	timestamp INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_currency_rates_pair ON currency_rates (league, currency_id, base_currency_id, timestamp);
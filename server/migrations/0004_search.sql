@@ -0,0 +1,49 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS items_fts USING fts5(
+	name,
+	base_type,
+	flavour_text,
+	descr_text,
+	implicit_mods,
+	explicit_mods,
+	content = 'items',
+	content_rowid = 'id'
+);
+
+CREATE TRIGGER IF NOT EXISTS items_fts_insert AFTER INSERT ON items BEGIN
+	INSERT INTO items_fts (rowid, name, base_type, flavour_text, descr_text, implicit_mods, explicit_mods)
+	VALUES (new.id, new.name, new.base_type, new.flavour_text, new.descr_text, new.implicit_mods, new.explicit_mods);
+END;
+
+CREATE TRIGGER IF NOT EXISTS items_fts_delete AFTER DELETE ON items BEGIN
+	INSERT INTO items_fts (items_fts, rowid, name, base_type, flavour_text, descr_text, implicit_mods, explicit_mods)
+	VALUES ('delete', old.id, old.name, old.base_type, old.flavour_text, old.descr_text, old.implicit_mods, old.explicit_mods);
+END;
+
+CREATE TRIGGER IF NOT EXISTS items_fts_update AFTER UPDATE ON items BEGIN
+	INSERT INTO items_fts (items_fts, rowid, name, base_type, flavour_text, descr_text, implicit_mods, explicit_mods)
+	VALUES ('delete', old.id, old.name, old.base_type, old.flavour_text, old.descr_text, old.implicit_mods, old.explicit_mods);
+	INSERT INTO items_fts (rowid, name, base_type, flavour_text, descr_text, implicit_mods, explicit_mods)
+	VALUES (new.id, new.name, new.base_type, new.flavour_text, new.descr_text, new.implicit_mods, new.explicit_mods);
+END;
+
+INSERT INTO items_fts (items_fts) VALUES ('rebuild');
+
+CREATE VIRTUAL TABLE IF NOT EXISTS stats_fts USING fts5(
+	text,
+	content = 'stats'
+);
+
+CREATE TRIGGER IF NOT EXISTS stats_fts_insert AFTER INSERT ON stats BEGIN
+	INSERT INTO stats_fts (rowid, text) VALUES (new.rowid, new.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS stats_fts_delete AFTER DELETE ON stats BEGIN
+	INSERT INTO stats_fts (stats_fts, rowid, text) VALUES ('delete', old.rowid, old.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS stats_fts_update AFTER UPDATE ON stats BEGIN
+	INSERT INTO stats_fts (stats_fts, rowid, text) VALUES ('delete', old.rowid, old.text);
+	INSERT INTO stats_fts (rowid, text) VALUES (new.rowid, new.text);
+END;
+
+INSERT INTO stats_fts (stats_fts) VALUES ('rebuild');
@@ -0,0 +1,6 @@
+ALTER TABLE queries ADD COLUMN lease_owner TEXT NOT NULL DEFAULT '';
+ALTER TABLE queries ADD COLUMN lease_expires_at INTEGER NOT NULL DEFAULT 0;
+
+UPDATE queries
+SET lease_expires_at = started_at + 300
+WHERE status = 'in_progress';
@@ -0,0 +1,2 @@
+ALTER TABLE queries ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE queries ADD COLUMN completed_at INTEGER NOT NULL DEFAULT 0;
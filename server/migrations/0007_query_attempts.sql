@@ -0,0 +1 @@
+ALTER TABLE queries ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE queries ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
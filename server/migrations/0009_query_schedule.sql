@@ -0,0 +1 @@
+ALTER TABLE queries ADD COLUMN schedule TEXT NOT NULL DEFAULT '';
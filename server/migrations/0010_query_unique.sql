@@ -0,0 +1,12 @@
+ALTER TABLE queries ADD COLUMN duplicate_of INTEGER NOT NULL DEFAULT 0;
+
+UPDATE queries AS d
+SET duplicate_of = c.id
+FROM (
+	SELECT MIN(id) AS id, item_id, league, run_once
+	FROM queries
+	GROUP BY item_id, league, run_once
+) AS c
+WHERE d.item_id = c.item_id AND d.league = c.league AND d.run_once = c.run_once AND d.id <> c.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_queries_unique ON queries (item_id, league, run_once) WHERE duplicate_of = 0;
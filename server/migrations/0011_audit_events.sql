@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp INTEGER NOT NULL,
+	caller TEXT NOT NULL DEFAULT '',
+	method TEXT NOT NULL,
+	fields TEXT NOT NULL DEFAULT '{}',
+	code TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_method ON audit_events (method, id);
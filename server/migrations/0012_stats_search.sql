@@ -0,0 +1,40 @@
+DROP TRIGGER IF EXISTS stats_fts_insert;
+DROP TRIGGER IF EXISTS stats_fts_delete;
+DROP TRIGGER IF EXISTS stats_fts_update;
+DROP TABLE IF EXISTS stats_fts;
+
+-- stats_fts is keyed on seq rather than the implicit rowid, which VACUUM may
+-- renumber in a table without an INTEGER PRIMARY KEY.
+CREATE TABLE stats_new (
+	seq INTEGER PRIMARY KEY,
+	id TEXT NOT NULL UNIQUE,
+	text TEXT NOT NULL DEFAULT '',
+	type TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO stats_new (id, text, type) SELECT id, text, type FROM stats;
+
+DROP TABLE stats;
+
+ALTER TABLE stats_new RENAME TO stats;
+
+CREATE VIRTUAL TABLE IF NOT EXISTS stats_fts USING fts5(
+	text,
+	content = 'stats',
+	content_rowid = 'seq'
+);
+
+CREATE TRIGGER IF NOT EXISTS stats_fts_insert AFTER INSERT ON stats BEGIN
+	INSERT INTO stats_fts (rowid, text) VALUES (new.seq, new.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS stats_fts_delete AFTER DELETE ON stats BEGIN
+	INSERT INTO stats_fts (stats_fts, rowid, text) VALUES ('delete', old.seq, old.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS stats_fts_update AFTER UPDATE ON stats BEGIN
+	INSERT INTO stats_fts (stats_fts, rowid, text) VALUES ('delete', old.seq, old.text);
+	INSERT INTO stats_fts (rowid, text) VALUES (new.seq, new.text);
+END;
+
+INSERT INTO stats_fts (stats_fts) VALUES ('rebuild');
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// pageSize clamps a requested page size to [1, maxPageSize], using
+// defaultPageSize when none was requested.
+func pageSize(requested uint32) int {
+	switch {
+	case requested == 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+// encodePageToken packs the keyset values of the last returned row into an
+// opaque token.
+func encodePageToken(keys ...int64) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = strconv.FormatInt(k, 10)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(parts, ":")))
+}
+
+// decodePageToken unpacks a token produced by encodePageToken. An empty token
+// decodes to nil, meaning the first page.
+func decodePageToken(token string, n int) ([]int64, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decoding page token: %w", err)
+	}
+
+	parts := strings.Split(string(data), ":")
+	if len(parts) != n {
+		return nil, fmt.Errorf("malformed page token")
+	}
+
+	keys := make([]int64, n)
+	for i, p := range parts {
+		keys[i], err = strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed page token: %w", err)
+		}
+	}
+
+	return keys, nil
+}
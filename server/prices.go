@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// candleIntervals are the bucket sizes GetPriceCandles accepts.
+var candleIntervals = map[string]time.Duration{
+	"1h": time.Hour,
+	"6h": 6 * time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// priceColumns is the column list read by scanPrice.
+const priceColumns = `
+	id, item_id, price, currency_id, volume, stock, league, timestamp`
+
+func scanPrice(r rowScanner) (*pb.Price, error) {
+	var p pb.Price
+
+	err := r.Scan(&p.Id, &p.ItemId, &p.Price, &p.CurrencyId, &p.Volume, &p.Stock, &p.League, &p.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (s *service) GetPriceHistory(ctx context.Context, hr *pb.PriceHistoryRequest) (*pb.PriceHistory, error) {
+	if hr.ItemId == "" || hr.League == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id and league are required")
+	}
+
+	cursor, err := decodePageToken(hr.PageToken, 2)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	afterTimestamp, afterID := int64(math.MinInt64), int64(0)
+	if cursor != nil {
+		afterTimestamp, afterID = cursor[0], cursor[1]
+	}
+
+	to := hr.To
+	if to == 0 {
+		to = math.MaxInt64
+	}
+
+	query := `
+	WITH` + convertedPricesCTE + `
+	SELECT` + priceColumns + `
+	FROM converted_prices
+	WHERE item_id = ? AND league = ? AND (? = '' OR source_currency_id = ?)
+		AND timestamp >= ? AND timestamp < ?
+		AND (timestamp > ? OR (timestamp = ? AND id > ?))
+		AND price IS NOT NULL
+	ORDER BY timestamp, id
+	LIMIT ?`
+
+	limit := pageSize(hr.PageSize)
+	args := append(convertedPricesArgs(hr.BaseCurrencyId), hr.ItemId, hr.League, hr.CurrencyId, hr.CurrencyId, hr.From, to, afterTimestamp, afterTimestamp, afterID, limit+1)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving price history for ItemId: %s: %s", hr.ItemId, err.Error())
+	}
+	defer rows.Close()
+
+	history := &pb.PriceHistory{}
+
+	for rows.Next() {
+		p, err := scanPrice(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning Price: %s: %s", hr.ItemId, err.Error())
+		}
+
+		history.Prices = append(history.Prices, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	if len(history.Prices) > limit {
+		history.Prices = history.Prices[:limit]
+		last := history.Prices[limit-1]
+		history.NextPageToken = encodePageToken(last.Timestamp, int64(last.Id))
+	}
+
+	return history, nil
+}
+
+func (s *service) GetPriceCandles(ctx context.Context, cr *pb.PriceCandlesRequest) (*pb.PriceCandles, error) {
+	if cr.ItemId == "" || cr.League == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id and league are required")
+	}
+
+	if cr.CurrencyId == "" && cr.BaseCurrencyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "currency_id or base_currency_id is required")
+	}
+
+	interval, ok := candleIntervals[cr.Interval]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported interval: %q", cr.Interval)
+	}
+
+	to := cr.To
+	if to == 0 {
+		to = math.MaxInt64
+	}
+
+	query := `
+	WITH` + convertedPricesCTE + `,
+	bucketed AS (
+		SELECT
+			(timestamp / ?) * ? AS bucket,
+			price,
+			volume,
+			FIRST_VALUE(price) OVER w AS open,
+			LAST_VALUE(price) OVER w AS close,
+			LAST_VALUE(stock) OVER w AS last_stock
+		FROM converted_prices
+		WHERE item_id = ? AND league = ? AND (? = '' OR source_currency_id = ?)
+			AND timestamp >= ? AND timestamp < ?
+			AND price IS NOT NULL
+		WINDOW w AS (
+			PARTITION BY timestamp / ?
+			ORDER BY timestamp, id
+			ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING
+		)
+	)
+	SELECT
+		bucket,
+		MAX(open),
+		MAX(price),
+		MIN(price),
+		MAX(close),
+		COALESCE(SUM(price * volume) / NULLIF(SUM(volume), 0), AVG(price)),
+		SUM(volume),
+		MAX(last_stock)
+	FROM bucketed
+	GROUP BY bucket
+	ORDER BY bucket`
+
+	secs := int64(interval.Seconds())
+
+	args := append(convertedPricesArgs(cr.BaseCurrencyId), secs, secs, cr.ItemId, cr.League, cr.CurrencyId, cr.CurrencyId, cr.From, to, secs)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving price candles for ItemId: %s: %s", cr.ItemId, err.Error())
+	}
+	defer rows.Close()
+
+	candles := &pb.PriceCandles{}
+
+	for rows.Next() {
+		var c pb.PriceCandle
+
+		err := rows.Scan(&c.Start, &c.Open, &c.High, &c.Low, &c.Close, &c.Vwap, &c.Volume, &c.Stock)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning PriceCandle: %s: %s", cr.ItemId, err.Error())
+		}
+
+		candles.Candles = append(candles.Candles, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	return candles, nil
+}
+
+func (s *service) GetLatestPrices(ctx context.Context, lr *pb.LatestPricesRequest) (*pb.LatestPrices, error) {
+	if lr.Category == "" || lr.League == "" {
+		return nil, status.Error(codes.InvalidArgument, "category and league are required")
+	}
+
+	query := `
+	WITH` + convertedPricesCTE + `
+	SELECT
+		i.id,
+		i.realm,
+		i.name,
+		i.base_type,
+		p.price,
+		p.currency_id,
+		p.volume,
+		p.stock,
+		p.timestamp
+	FROM items i
+	JOIN converted_prices p ON p.id = (
+		SELECT id
+		FROM prices
+		WHERE item_id = CAST(i.id AS TEXT) AND league = ?
+		ORDER BY timestamp DESC, id DESC
+		LIMIT 1
+	)
+	WHERE i.category = ? AND p.price IS NOT NULL
+	ORDER BY i.id`
+
+	args := append(convertedPricesArgs(lr.BaseCurrencyId), lr.League, lr.Category)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving latest prices: %s: %s", lr.Category, err.Error())
+	}
+	defer rows.Close()
+
+	prices := &pb.LatestPrices{}
+
+	for rows.Next() {
+		p := pb.LatestPrice{Item: &pb.BaseItem{}}
+
+		err := rows.Scan(&p.Item.Id, &p.Item.Realm, &p.Item.Name, &p.Item.BaseType, &p.Price, &p.CurrencyId, &p.Volume, &p.Stock, &p.Timestamp)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning LatestPrice: %s: %s", lr.Category, err.Error())
+		}
+
+		prices.Prices = append(prices.Prices, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	return prices, nil
+}
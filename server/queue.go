@@ -0,0 +1,636 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+const (
+	defaultLeaseBatch = 4
+	maxLeaseBatch     = 100
+	defaultLease      = 5 * time.Minute
+	maxLease          = 24 * time.Hour
+
+	// A failed query is retried after retryBackoff, doubling with every
+	// attempt up to maxRetryBackoff.
+	retryBackoff    = time.Minute
+	maxRetryBackoff = 6 * time.Hour
+)
+
+// queryColumns is the column list read by scanQuery.
+const queryColumns = `
+	id, item_id, realm, league, search_query, update_interval, next_run, status, started_at, run_once, lease_owner, lease_expires_at,
+	last_error, completed_at, attempts, priority, schedule, duplicate_of`
+
+func scanQuery(r rowScanner) (*pb.Query, error) {
+	var q pb.Query
+
+	err := r.Scan(&q.Id, &q.ItemId, &q.Realm, &q.League, &q.Query, &q.Update, &q.NextRun, &q.Status, &q.StartedAt, &q.RunOnce, &q.LeaseOwner, &q.LeaseExpiresAt,
+		&q.LastError, &q.CompletedAt, &q.Attempts, &q.Priority, &q.Schedule, &q.DuplicateOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &q, nil
+}
+
+// upsertQuery inserts q, or updates the search and schedule of the existing
+// query with the same item_id, league and run_once, and returns the stored
+// row. An empty schedule keeps the one already set, and a run_once query that
+// has completed is queued to run again at q.NextRun.
+func (s *service) upsertQuery(q *pb.Query) (*pb.QueryResult, error) {
+	if q.Schedule != "" {
+		if _, err := parseSchedule(q.Schedule); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "upserting query for ItemId: %s: %s", q.ItemId, err.Error())
+	}
+	defer tx.Rollback()
+
+	existingQuery := `
+	SELECT id
+	FROM queries
+	WHERE item_id = ? AND league = ? AND run_once = ? AND duplicate_of = 0`
+
+	result := &pb.QueryResult{}
+
+	err = tx.QueryRow(existingQuery, q.ItemId, q.League, q.RunOnce).Scan(&result.Id)
+	switch {
+	case err == sql.ErrNoRows:
+		query := `
+		INSERT INTO queries (item_id, realm, league, search_query, update_interval, next_run, run_once, priority, schedule)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING` + queryColumns
+
+		result.Query, err = scanQuery(tx.QueryRow(query, q.ItemId, q.Realm, q.League, q.Query, q.Update, q.NextRun, q.RunOnce, q.Priority, q.Schedule))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "inserting query for ItemId: %s: %s", q.ItemId, err.Error())
+		}
+
+		result.Id = result.Query.Id
+		result.Created = true
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "upserting query for ItemId: %s: %s", q.ItemId, err.Error())
+	default:
+		query := `
+		UPDATE queries
+		SET
+			search_query = ?,
+			update_interval = ?,
+			schedule = CASE WHEN ? = '' THEN schedule ELSE ? END,
+			next_run = CASE WHEN status = 'done' THEN ? ELSE next_run END,
+			status = CASE WHEN status = 'done' THEN 'queued' ELSE status END
+		WHERE id = ?
+		RETURNING` + queryColumns
+
+		result.Query, err = scanQuery(tx.QueryRow(query, q.Query, q.Update, q.Schedule, q.Schedule, q.NextRun, result.Id))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "updating query %d: %s", result.Id, err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "upserting query for ItemId: %s: %s", q.ItemId, err.Error())
+	}
+
+	s.queue.notify()
+
+	return result, nil
+}
+
+func (s *service) UpsertQuery(ctx context.Context, q *pb.Query) (*pb.QueryResult, error) {
+	return s.upsertQuery(q)
+}
+
+// leaseParams applies defaults and bounds to a LeaseRequest.
+func leaseParams(lr *pb.LeaseRequest) (batch int, lease time.Duration) {
+	batch = int(lr.BatchSize)
+	switch {
+	case batch == 0:
+		batch = defaultLeaseBatch
+	case batch > maxLeaseBatch:
+		batch = maxLeaseBatch
+	}
+
+	return batch, leaseDuration(lr.LeaseSeconds)
+}
+
+func leaseDuration(seconds uint32) time.Duration {
+	lease := time.Duration(seconds) * time.Second
+	switch {
+	case lease == 0:
+		lease = defaultLease
+	case lease > maxLease:
+		lease = maxLease
+	}
+
+	return lease
+}
+
+// notLeased is returned when a worker acts on a query it does not hold.
+func notLeased(id uint64, workerID string) error {
+	return status.Errorf(codes.FailedPrecondition, "query %d is not leased by %q", id, workerID)
+}
+
+// checkLeased turns an UPDATE that matched no leased row into notLeased.
+func checkLeased(res sql.Result, id uint64, workerID string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return status.Errorf(codes.Internal, "checking lease for query %d: %s", id, err.Error())
+	}
+
+	if n == 0 {
+		return notLeased(id, workerID)
+	}
+
+	return nil
+}
+
+// expireLeases returns queries whose lease ran out to the queue, or moves them
+// to the dead state once they have used up their attempts, and reports how
+// many it touched. Requeued queries keep the error of their last failure.
+func (s *service) expireLeases(now time.Time) (int, error) {
+	query := `
+	UPDATE queries
+	SET
+		status = CASE WHEN attempts >= ? THEN 'dead' ELSE 'queued' END,
+		started_at = 0,
+		lease_owner = '',
+		lease_expires_at = 0,
+		last_error = CASE WHEN attempts >= ? THEN 'lease expired' ELSE last_error END
+	WHERE status = 'in_progress' AND lease_expires_at < ?
+	RETURNING status`
+
+	rows, err := s.db.Query(query, s.maxAttempts, s.maxAttempts, now.Unix())
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "expiring leases: %s", err.Error())
+	}
+	defer rows.Close()
+
+	n := 0
+
+	for rows.Next() {
+		var outcome string
+		if err := rows.Scan(&outcome); err != nil {
+			return n, status.Errorf(codes.Internal, "expiring leases: %s", err.Error())
+		}
+
+		leaseExpiries.WithLabelValues(outcome).Inc()
+		n++
+	}
+
+	if err := rows.Err(); err != nil {
+		return n, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	return n, nil
+}
+
+// sweepLeases runs expireLeases every interval until ctx is done, so expired
+// leases are counted and requeued even when no worker is asking for work.
+func (s *service) sweepLeases(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.expireLeases(time.Now().UTC())
+		if err != nil {
+			slog.Error("sweeping expired leases", "error", err)
+			continue
+		}
+
+		if n > 0 {
+			s.queue.notify()
+		}
+	}
+}
+
+// leaseQueries marks up to the requested batch of due queries as in progress
+// for lr.WorkerId, counting an attempt for each. Queries whose lease has
+// expired are handed out again unless they have run out of attempts, in which
+// case they are moved to the dead state.
+//
+// Higher priorities always go first. Within a priority, queries are taken
+// round-robin across (realm, league) so a league with many queries cannot
+// starve the others.
+//
+// Realms with a rate limit never hand out more queries than their budget
+// allows. When the budget holds back due queries, Queries.RetryAfterMs says
+// when the next token for one of their realms is available.
+func (s *service) leaseQueries(lr *pb.LeaseRequest, runOnce bool) (*pb.Queries, error) {
+	now := time.Now().UTC()
+
+	if _, err := s.expireLeases(now); err != nil {
+		return nil, err
+	}
+
+	query := `
+	UPDATE queries
+	SET status = 'in_progress', started_at = ?, lease_owner = ?, lease_expires_at = ?, attempts = attempts + 1
+	WHERE id IN (
+		SELECT c.id
+		FROM (
+			SELECT
+				id,
+				realm,
+				priority,
+				turn,
+				ROW_NUMBER() OVER (PARTITION BY realm ORDER BY priority DESC, turn, id) AS realm_turn
+			FROM (
+				SELECT
+					id,
+					realm,
+					priority,
+					ROW_NUMBER() OVER (PARTITION BY realm, league, priority ORDER BY next_run, id) AS turn
+				FROM queries
+				WHERE status = 'queued' AND next_run < ? AND run_once = ?
+			)
+		) c
+		LEFT JOIN json_each(?) budget ON budget.key = c.realm
+		WHERE budget.value IS NULL OR c.realm_turn <= budget.value
+		ORDER BY c.priority DESC, c.turn, c.id
+		LIMIT ?
+	)
+	RETURNING` + queryColumns
+
+	batch, lease := leaseParams(lr)
+
+	allowances := "{}"
+	if s.limiter != nil {
+		s.limiter.mu.Lock()
+		defer s.limiter.mu.Unlock()
+
+		allowances = s.limiter.allowances(now)
+	}
+
+	rows, err := s.db.Query(query, now.Unix(), lr.WorkerId, now.Add(lease).Unix(), now.Unix(), runOnce, allowances, batch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "leasing queries: %s", err.Error())
+	}
+	defer rows.Close()
+
+	queries := &pb.Queries{}
+
+	for rows.Next() {
+		q, err := scanQuery(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning Query: %s", err.Error())
+		}
+
+		queries.Queries = append(queries.Queries, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+	rows.Close()
+
+	if s.limiter != nil {
+		for _, q := range queries.Queries {
+			s.limiter.take(q.Realm, 1)
+		}
+
+		if len(queries.Queries) < batch {
+			realms, err := s.heldBackRealms(now, runOnce, allowances)
+			if err != nil {
+				return nil, err
+			}
+
+			queries.RetryAfterMs = s.limiter.retryAfter(now, realms).Milliseconds()
+		}
+	}
+
+	return queries, nil
+}
+
+// heldBackRealms returns the rate-limited realms that still have due queries
+// after a lease that came back short, which can only be because their budget
+// held them back.
+func (s *service) heldBackRealms(now time.Time, runOnce bool, allowances string) ([]string, error) {
+	query := `
+	SELECT DISTINCT realm
+	FROM queries
+	WHERE status = 'queued' AND next_run < ? AND run_once = ?
+		AND realm IN (SELECT key FROM json_each(?))`
+
+	rows, err := s.db.Query(query, now.Unix(), runOnce, allowances)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "finding rate-limited realms: %s", err.Error())
+	}
+	defer rows.Close()
+
+	var realms []string
+
+	for rows.Next() {
+		var realm string
+		if err := rows.Scan(&realm); err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning realm: %s", err.Error())
+		}
+
+		realms = append(realms, realm)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	return realms, nil
+}
+
+func (s *service) ExtendLease(ctx context.Context, er *pb.ExtendLeaseRequest) (*pb.Query, error) {
+	query := `
+	UPDATE queries
+	SET lease_expires_at = ?
+	WHERE id = ? AND status = 'in_progress' AND lease_owner = ?
+	RETURNING` + queryColumns
+
+	expires := time.Now().Add(leaseDuration(er.LeaseSeconds)).UTC().Unix()
+
+	q, err := scanQuery(s.db.QueryRow(query, expires, er.Id, er.WorkerId))
+	if err == sql.ErrNoRows {
+		return nil, notLeased(er.Id, er.WorkerId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "extending lease for query %d: %s", er.Id, err.Error())
+	}
+
+	return q, nil
+}
+
+// CompleteQuery releases the lease after a successful run. Recurring queries
+// are queued for their next scheduled run; run-once queries are marked done.
+func (s *service) CompleteQuery(ctx context.Context, cr *pb.QueryLeaseRequest) (*pb.Empty, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "completing query %d: %s", cr.Id, err.Error())
+	}
+	defer tx.Rollback()
+
+	leasedQuery := `
+	SELECT schedule, update_interval, run_once, next_run
+	FROM queries
+	WHERE id = ? AND status = 'in_progress' AND lease_owner = ?`
+
+	var (
+		schedule    string
+		updateHours uint32
+		runOnce     bool
+		next        int64
+	)
+
+	err = tx.QueryRow(leasedQuery, cr.Id, cr.WorkerId).Scan(&schedule, &updateHours, &runOnce, &next)
+	if err == sql.ErrNoRows {
+		return nil, notLeased(cr.Id, cr.WorkerId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "completing query %d: %s", cr.Id, err.Error())
+	}
+
+	now := time.Now()
+	state := "done"
+
+	if !runOnce {
+		state = "queued"
+
+		next, err = nextRun(schedule, updateHours, now, s.maxJitter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scheduling query %d: %s", cr.Id, err.Error())
+		}
+	}
+
+	query := `
+	UPDATE queries
+	SET
+		status = ?,
+		next_run = ?,
+		started_at = 0,
+		lease_owner = '',
+		lease_expires_at = 0,
+		last_error = '',
+		completed_at = ?,
+		attempts = 0
+	WHERE id = ?`
+
+	if _, err := tx.Exec(query, state, next, now.UTC().Unix(), cr.Id); err != nil {
+		return nil, status.Errorf(codes.Internal, "completing query %d: %s", cr.Id, err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "completing query %d: %s", cr.Id, err.Error())
+	}
+
+	s.queue.notify()
+
+	return &pb.Empty{}, nil
+}
+
+// FailQuery releases the lease after a failed run and records the error. The
+// query is retried with exponential backoff until it has used maxAttempts, at
+// which point it is moved to the dead state.
+func (s *service) FailQuery(ctx context.Context, fr *pb.FailQueryRequest) (*pb.Empty, error) {
+	query := `
+	UPDATE queries
+	SET
+		status = CASE WHEN attempts >= ? THEN 'dead' ELSE 'queued' END,
+		next_run = ? + MIN(? << MIN(MAX(attempts - 1, 0), 30), ?),
+		started_at = 0,
+		lease_owner = '',
+		lease_expires_at = 0,
+		last_error = ?
+	WHERE id = ? AND status = 'in_progress' AND lease_owner = ?`
+
+	now := time.Now().UTC().Unix()
+	backoff, maxBackoff := int64(retryBackoff.Seconds()), int64(maxRetryBackoff.Seconds())
+
+	res, err := s.db.Exec(query, s.maxAttempts, now, backoff, maxBackoff, fr.Error, fr.Id, fr.WorkerId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failing query %d: %s", fr.Id, err.Error())
+	}
+
+	if err := checkLeased(res, fr.Id, fr.WorkerId); err != nil {
+		return nil, err
+	}
+
+	s.queue.notify()
+
+	return &pb.Empty{}, nil
+}
+
+func (s *service) ListDeadQueries(ctx context.Context, pr *pb.PageRequest) (*pb.Queries, error) {
+	return s.listQueriesWhere(pr.PageSize, pr.PageToken, "status = 'dead'")
+}
+
+// listQueriesWhere pages through the queries matching cond, which is bound
+// with args.
+func (s *service) listQueriesWhere(size uint32, token string, cond string, args ...any) (*pb.Queries, error) {
+	cursor, err := decodePageToken(token, 1)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	afterID := int64(0)
+	if cursor != nil {
+		afterID = cursor[0]
+	}
+
+	query := `
+	SELECT` + queryColumns + `
+	FROM queries
+	WHERE ` + cond + ` AND id > ?
+	ORDER BY id
+	LIMIT ?`
+
+	limit := pageSize(size)
+
+	rows, err := s.db.Query(query, append(args, afterID, limit+1)...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving queries: %s", err.Error())
+	}
+	defer rows.Close()
+
+	queries := &pb.Queries{}
+
+	for rows.Next() {
+		q, err := scanQuery(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning Query: %s", err.Error())
+		}
+
+		queries.Queries = append(queries.Queries, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	if len(queries.Queries) > limit {
+		queries.Queries = queries.Queries[:limit]
+		queries.NextPageToken = encodePageToken(int64(queries.Queries[limit-1].Id))
+	}
+
+	return queries, nil
+}
+
+// RequeueQuery moves a dead query back to the queue with a fresh set of
+// attempts. The last error is kept until the query next succeeds.
+func (s *service) RequeueQuery(ctx context.Context, qr *pb.QueryIDRequest) (*pb.Empty, error) {
+	query := `
+	UPDATE queries
+	SET status = 'queued', next_run = ?, attempts = 0
+	WHERE id = ? AND status = 'dead'`
+
+	res, err := s.db.Exec(query, time.Now().UTC().Unix(), qr.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "requeueing query %d: %s", qr.Id, err.Error())
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "requeueing query %d: %s", qr.Id, err.Error())
+	}
+
+	if n == 0 {
+		return nil, status.Errorf(codes.NotFound, "no dead query with id %d", qr.Id)
+	}
+
+	s.queue.notify()
+
+	return &pb.Empty{}, nil
+}
+
+// ListDuplicateQueries reports the queries flagged as duplicates when the
+// unique constraint was introduced, so they can be reviewed and deleted.
+func (s *service) ListDuplicateQueries(ctx context.Context, pr *pb.PageRequest) (*pb.Queries, error) {
+	return s.listQueriesWhere(pr.PageSize, pr.PageToken, "duplicate_of <> 0")
+}
+
+// ListQueries pages through all queries by id. Empty filters match anything.
+func (s *service) ListQueries(ctx context.Context, lr *pb.ListQueriesRequest) (*pb.Queries, error) {
+	cond := `(? = '' OR status = ?)
+		AND (? = '' OR league = ?)
+		AND (? = '' OR realm = ?)
+		AND (? = '' OR item_id = ?)
+		AND (? IS NULL OR run_once = ?)`
+
+	return s.listQueriesWhere(lr.PageSize, lr.PageToken, cond,
+		lr.Status, lr.Status,
+		lr.League, lr.League,
+		lr.Realm, lr.Realm,
+		lr.ItemId, lr.ItemId,
+		lr.RunOnce, lr.RunOnce,
+	)
+}
+
+// PauseQuery takes a query out of the lease rotation without deleting it. A
+// lease held on the query is dropped, so the worker can no longer extend,
+// complete or fail it. Dead queries go through RequeueQuery instead.
+func (s *service) PauseQuery(ctx context.Context, qr *pb.QueryIDRequest) (*pb.Query, error) {
+	query := `
+	UPDATE queries
+	SET status = 'paused', started_at = 0, lease_owner = '', lease_expires_at = 0
+	WHERE id = ? AND status IN ('queued', 'in_progress')
+	RETURNING` + queryColumns
+
+	return s.setQueryState(qr.Id, "pausing", query)
+}
+
+// ResumeQuery puts a paused query back in the queue. It keeps its next_run, so
+// a query that came due while paused is leased straight away.
+func (s *service) ResumeQuery(ctx context.Context, qr *pb.QueryIDRequest) (*pb.Query, error) {
+	query := `
+	UPDATE queries
+	SET status = 'queued'
+	WHERE id = ? AND status = 'paused'
+	RETURNING` + queryColumns
+
+	q, err := s.setQueryState(qr.Id, "resuming", query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.queue.notify()
+
+	return q, nil
+}
+
+// setQueryState runs a status transition on query id, returning the updated
+// row. When no row matches it reports NotFound for a missing query and
+// FailedPrecondition for one in the wrong state.
+func (s *service) setQueryState(id uint64, action string, query string) (*pb.Query, error) {
+	q, err := scanQuery(s.db.QueryRow(query, id))
+	if err == nil {
+		return q, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, status.Errorf(codes.Internal, "%s query %d: %s", action, id, err.Error())
+	}
+
+	var state string
+
+	err = s.db.QueryRow(`SELECT status FROM queries WHERE id = ?`, id).Scan(&state)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "no query with id %d", id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s query %d: %s", action, id, err.Error())
+	}
+
+	return nil, status.Errorf(codes.FailedPrecondition, "%s query %d: status is %s", action, id, state)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket holding up to capacity tokens, refilled at rate
+// tokens per second.
+type bucket struct {
+	capacity float64
+	rate     float64
+	tokens   float64
+	last     time.Time
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// limiter enforces the trade API budget of each configured realm. Realms
+// without a bucket are not limited. Callers hold mu across reading the
+// allowances and taking the tokens for what was actually leased.
+type limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// parseRateLimits parses a comma-separated list of realm=requests/seconds,
+// e.g. "poe2=10/60,pc=30/300". An empty spec yields a nil limiter.
+func parseRateLimits(spec string, now time.Time) (*limiter, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	l := &limiter{buckets: map[string]*bucket{}}
+
+	for _, entry := range strings.Split(spec, ",") {
+		realm, budget, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("rate limit %q: expected realm=requests/seconds", entry)
+		}
+
+		reqs, secs, ok := strings.Cut(budget, "/")
+		if !ok {
+			return nil, fmt.Errorf("rate limit %q: expected realm=requests/seconds", entry)
+		}
+
+		requests, err := strconv.Atoi(reqs)
+		if err != nil || requests <= 0 {
+			return nil, fmt.Errorf("rate limit %q: invalid request count", entry)
+		}
+
+		seconds, err := strconv.Atoi(secs)
+		if err != nil || seconds <= 0 {
+			return nil, fmt.Errorf("rate limit %q: invalid period", entry)
+		}
+
+		l.buckets[realm] = &bucket{
+			capacity: float64(requests),
+			rate:     float64(requests) / float64(seconds),
+			tokens:   float64(requests),
+			last:     now,
+		}
+	}
+
+	return l, nil
+}
+
+// allowances returns, as a JSON object for json_each, how many queries each
+// limited realm may hand out now.
+func (l *limiter) allowances(now time.Time) string {
+	allow := make(map[string]int, len(l.buckets))
+
+	for realm, b := range l.buckets {
+		b.refill(now)
+		allow[realm] = int(math.Floor(b.tokens))
+	}
+
+	data, _ := json.Marshal(allow)
+
+	return string(data)
+}
+
+// take spends one token per leased query.
+func (l *limiter) take(realm string, n int) {
+	if b, ok := l.buckets[realm]; ok {
+		b.tokens -= float64(n)
+	}
+}
+
+// retryAfter returns how long until the soonest of realms that is exhausted
+// has a token again, or zero if none of them is.
+func (l *limiter) retryAfter(now time.Time, realms []string) time.Duration {
+	var wait time.Duration
+
+	for _, realm := range realms {
+		b, ok := l.buckets[realm]
+		if !ok {
+			continue
+		}
+
+		b.refill(now)
+
+		if b.tokens >= 1 {
+			continue
+		}
+
+		d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		if wait == 0 || d < wait {
+			wait = d
+		}
+	}
+
+	return wait
+}
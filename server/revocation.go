@@ -0,0 +1,164 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// revocations holds the client certificate serials that must be rejected:
+// those listed in a CRL, scoped to the CRL's issuer, and those on the deny
+// list, rejected whatever CA issued them. The deny list names client
+// certificates, so it is only matched against the leaf of a chain.
+type revocations struct {
+	revoked map[string]bool
+	denied  map[string]bool
+}
+
+// parseSerials parses a comma-separated list of hex serial numbers, with or
+// without the colons openssl prints between bytes.
+func parseSerials(spec string) (map[string]bool, error) {
+	serials := map[string]bool{}
+
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		n, ok := new(big.Int).SetString(strings.ReplaceAll(s, ":", ""), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid certificate serial %q", s)
+		}
+
+		serials[n.String()] = true
+	}
+
+	return serials, nil
+}
+
+// loadRevocations reads the PEM or DER encoded CRLs in crlFile, which must be
+// signed by one of the CA certificates in caPEM. An empty crlFile only applies
+// the deny list.
+func loadRevocations(crlFile string, caPEM []byte, denied map[string]bool) (*revocations, error) {
+	r := &revocations{revoked: map[string]bool{}, denied: denied}
+
+	if crlFile == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(crlFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading CRL: %w", err)
+	}
+
+	var ders [][]byte
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "X509 CRL" {
+			ders = append(ders, block.Bytes)
+		}
+	}
+	if len(ders) == 0 {
+		ders = append(ders, data)
+	}
+
+	var cas []*x509.Certificate
+	for block, rest := pem.Decode(caPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		ca, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CA certificate: %w", err)
+		}
+
+		cas = append(cas, ca)
+	}
+
+	for _, der := range ders {
+		crl, err := x509.ParseRevocationList(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CRL: %w", err)
+		}
+
+		if err := checkCRLSignature(crl, cas); err != nil {
+			return nil, err
+		}
+
+		if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+			slog.Warn("CRL is past its next update", "issuer", crl.Issuer.String(), "next_update", crl.NextUpdate)
+		}
+
+		for _, e := range crl.RevokedCertificateEntries {
+			r.revoked[revocationKey(crl.RawIssuer, e.SerialNumber)] = true
+		}
+	}
+
+	return r, nil
+}
+
+func checkCRLSignature(crl *x509.RevocationList, cas []*x509.Certificate) error {
+	for _, ca := range cas {
+		if string(ca.RawSubject) != string(crl.RawIssuer) {
+			continue
+		}
+
+		if err := crl.CheckSignatureFrom(ca); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("CRL issued by %s is not signed by a trusted CA", crl.Issuer.String())
+}
+
+func revocationKey(issuer []byte, serial *big.Int) string {
+	return string(issuer) + "/" + serial.String()
+}
+
+func (r *revocations) isRevoked(cert *x509.Certificate) bool {
+	return r.revoked[revocationKey(cert.RawIssuer, cert.SerialNumber)]
+}
+
+// verify is a tls.Config VerifyConnection hook. Unlike VerifyPeerCertificate
+// it also runs when a client resumes a session, so a revoked certificate
+// cannot get back in with a session ticket issued before it was revoked. It
+// accepts the handshake if at least one verified chain contains no revoked
+// certificate.
+func (r *revocations) verify(cs tls.ConnectionState) error {
+	chains := cs.VerifiedChains
+	if len(chains) == 0 {
+		return nil
+	}
+
+	for _, chain := range chains {
+		if !r.chainRevoked(chain) {
+			return nil
+		}
+	}
+
+	leaf := chains[0][0]
+	slog.Warn("rejected revoked client certificate", "subject", leaf.Subject.String(), "serial", fmt.Sprintf("%x", leaf.SerialNumber))
+
+	return fmt.Errorf("client certificate %x is revoked", leaf.SerialNumber)
+}
+
+func (r *revocations) chainRevoked(chain []*x509.Certificate) bool {
+	if r.denied[chain[0].SerialNumber.String()] {
+		return true
+	}
+
+	for _, cert := range chain {
+		if r.isRevoked(cert) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a PEM certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, serial int64, cn string) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// crl returns a PEM CRL signed by the CA revoking serials.
+func (ca *testCA) crl(t *testing.T, number int64, serials ...int64) []byte {
+	t.Helper()
+
+	var entries []x509.RevocationListEntry
+	for _, s := range serials {
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: big.NewInt(s), RevocationTime: time.Now()})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(number),
+		ThisUpdate:                time.Now(),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}, ca.cert, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// serveTLS accepts connections on a local listener using config, writing one
+// byte to every client that completes the handshake.
+func serveTLS(t *testing.T, config *tls.Config) string {
+	t.Helper()
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				if err := conn.(*tls.Conn).Handshake(); err != nil {
+					return
+				}
+
+				conn.Write([]byte{1})
+			}()
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+// dialTLS connects and reads the server's byte, which also processes the
+// session ticket and surfaces a rejected client certificate.
+func dialTLS(addr string, config *tls.Config) (tls.ConnectionState, error) {
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	return conn.ConnectionState(), nil
+}
+
+func TestRevokedCertificateCannotResumeSession(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	crlFile := filepath.Join(dir, "ca.crl")
+
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, 2, "server")
+	clientCert, clientKey := ca.issue(t, 3, "scraper")
+
+	writeTestFile(t, certFile, serverCert)
+	writeTestFile(t, keyFile, serverKey)
+	writeTestFile(t, caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}))
+	writeTestFile(t, crlFile, ca.crl(t, 1))
+
+	certs, err := newTLSReloader(certFile, keyFile, caFile, crlFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := serveTLS(t, certs.serverConfig())
+
+	pair, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	client := &tls.Config{
+		Certificates:       []tls.Certificate{pair},
+		RootCAs:            roots,
+		ServerName:         "server",
+		ClientSessionCache: tls.NewLRUClientSessionCache(1),
+	}
+
+	if _, err := dialTLS(addr, client); err != nil {
+		t.Fatalf("first handshake: %v", err)
+	}
+
+	cs, err := dialTLS(addr, client)
+	if err != nil {
+		t.Fatalf("resumed handshake: %v", err)
+	}
+	if !cs.DidResume {
+		t.Fatal("second handshake did not resume the session")
+	}
+
+	writeTestFile(t, crlFile, ca.crl(t, 2, 3))
+	if err := certs.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dialTLS(addr, client); err == nil {
+		t.Fatal("revoked certificate resumed its session")
+	}
+
+	client.ClientSessionCache = nil
+	if _, err := dialTLS(addr, client); err == nil {
+		t.Fatal("revoked certificate completed a full handshake")
+	}
+}
+
+func TestDenyListOnlyMatchesClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, 2, "server")
+	clientCert, clientKey := ca.issue(t, 3, "scraper")
+	deniedCert, deniedKey := ca.issue(t, 4, "compromised")
+
+	writeTestFile(t, certFile, serverCert)
+	writeTestFile(t, keyFile, serverKey)
+	writeTestFile(t, caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}))
+
+	// The CA's own serial is 1, so denying it must not lock out the clients
+	// it issued.
+	denied, err := parseSerials("01,04")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := newTLSReloader(certFile, keyFile, caFile, "", denied)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := serveTLS(t, certs.serverConfig())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	dial := func(certPEM, keyPEM []byte) error {
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = dialTLS(addr, &tls.Config{Certificates: []tls.Certificate{pair}, RootCAs: roots, ServerName: "server"})
+		return err
+	}
+
+	if err := dial(clientCert, clientKey); err != nil {
+		t.Fatalf("client issued by a denied CA serial was rejected: %v", err)
+	}
+
+	if err := dial(deniedCert, deniedKey); err == nil {
+		t.Fatal("denied client certificate completed a handshake")
+	}
+}
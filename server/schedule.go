@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// parseSchedule accepts either a whole number of minutes or a standard cron
+// expression, including descriptors such as @daily and @every 15m. Cron
+// expressions are evaluated in UTC, and one that never matches a date, such
+// as the 30th of February, is rejected.
+func parseSchedule(schedule string) (cron.Schedule, error) {
+	if minutes, err := strconv.Atoi(schedule); err == nil {
+		if minutes <= 0 {
+			return nil, fmt.Errorf("schedule interval must be positive: %d", minutes)
+		}
+
+		return cron.Every(time.Duration(minutes) * time.Minute), nil
+	}
+
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("parsing schedule %q: %w", schedule, err)
+	}
+
+	if sched.Next(time.Now().UTC()).IsZero() {
+		return nil, fmt.Errorf("schedule %q never runs", schedule)
+	}
+
+	return sched, nil
+}
+
+// nextRun returns the unix time of the run after now. Queries without a
+// schedule fall back to updateHours. Up to a tenth of the gap between runs,
+// capped at maxJitter, is added so queries sharing a schedule do not all come
+// due at once.
+func nextRun(schedule string, updateHours uint32, now time.Time, maxJitter time.Duration) (int64, error) {
+	now = now.UTC()
+
+	var next, after time.Time
+
+	if schedule == "" {
+		next = now.Add(time.Duration(updateHours) * time.Hour)
+		after = next.Add(time.Duration(updateHours) * time.Hour)
+	} else {
+		sched, err := parseSchedule(schedule)
+		if err != nil {
+			return 0, err
+		}
+
+		next = sched.Next(now)
+		after = sched.Next(next)
+	}
+
+	if jitter := min(after.Sub(next)/10, maxJitter); jitter > 0 {
+		next = next.Add(rand.N(jitter))
+	}
+
+	return next.Unix(), nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// ftsQuery turns free text into an FTS5 query matching every term, quoting
+// each one so user input cannot inject FTS5 syntax.
+func ftsQuery(text string) string {
+	terms := strings.Fields(text)
+	for i, t := range terms {
+		terms[i] = `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+	}
+
+	return strings.Join(terms, " ")
+}
+
+func (s *service) SearchItems(ctx context.Context, sr *pb.SearchRequest) (*pb.SearchResults, error) {
+	match := ftsQuery(sr.Query)
+	if match == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	limit := pageSize(sr.Limit)
+	results := &pb.SearchResults{}
+
+	itemsQuery := `
+	SELECT
+		i.id,
+		i.realm,
+		i.name,
+		i.base_type,
+		snippet(items_fts, -1, '[', ']', '...', 12),
+		bm25(items_fts)
+	FROM items_fts
+	JOIN items i ON i.id = items_fts.rowid
+	WHERE items_fts MATCH ? AND (? = '' OR i.category = ?)
+	ORDER BY bm25(items_fts)
+	LIMIT ?`
+
+	rows, err := s.db.Query(itemsQuery, match, sr.Category, sr.Category, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "searching items: %s: %s", sr.Query, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		h := pb.ItemHit{Item: &pb.BaseItem{}}
+
+		err := rows.Scan(&h.Item.Id, &h.Item.Realm, &h.Item.Name, &h.Item.BaseType, &h.Snippet, &h.Rank)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning ItemHit: %s: %s", sr.Query, err.Error())
+		}
+
+		results.Items = append(results.Items, &h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	modsQuery := `
+	SELECT
+		st.id,
+		st.text,
+		st.type,
+		snippet(stats_fts, 0, '[', ']', '...', 12),
+		bm25(stats_fts)
+	FROM stats_fts
+	JOIN stats st ON st.seq = stats_fts.rowid
+	WHERE stats_fts MATCH ?
+	ORDER BY bm25(stats_fts)
+	LIMIT ?`
+
+	modRows, err := s.db.Query(modsQuery, match, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "searching mods: %s: %s", sr.Query, err.Error())
+	}
+	defer modRows.Close()
+
+	for modRows.Next() {
+		h := pb.ModHit{Stat: &pb.Stats{}}
+
+		err := modRows.Scan(&h.Stat.Id, &h.Stat.Text, &h.Stat.Type, &h.Snippet, &h.Rank)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scaning ModHit: %s: %s", sr.Query, err.Error())
+		}
+
+		results.Mods = append(results.Mods, &h)
+	}
+
+	if err := modRows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "iteration error: %s", err.Error())
+	}
+
+	return results, nil
+}
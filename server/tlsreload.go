@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// tlsReloader keeps the server certificate, client CA pool and CRL in sync
+// with the files they were loaded from. New handshakes read the current config
+// through GetConfigForClient, so swapping it leaves established connections
+// and their streams alone.
+type tlsReloader struct {
+	certFile, keyFile, caFile, crlFile string
+	denied                             map[string]bool
+
+	config atomic.Pointer[tls.Config]
+
+	mu       sync.Mutex
+	modTimes []time.Time
+}
+
+func newTLSReloader(certFile, keyFile, caFile, crlFile string, denied map[string]bool) (*tlsReloader, error) {
+	r := &tlsReloader{certFile: certFile, keyFile: keyFile, caFile: caFile, crlFile: crlFile, denied: denied}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.certFile, r.keyFile, r.caFile}
+	if r.crlFile != "" {
+		files = append(files, r.crlFile)
+	}
+
+	return files
+}
+
+// reload loads the files and swaps in the new config. On error the previous
+// config stays in use.
+func (r *tlsReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTimes := r.stat()
+
+	config, err := createTLSConfig(r.certFile, r.keyFile, r.caFile, r.crlFile, r.denied)
+	if err != nil {
+		return err
+	}
+
+	r.config.Store(config)
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// stat returns the modification time of each file, zero for one that cannot
+// be read.
+func (r *tlsReloader) stat() []time.Time {
+	var modTimes []time.Time
+
+	for _, name := range r.files() {
+		var t time.Time
+		if fi, err := os.Stat(name); err == nil {
+			t = fi.ModTime()
+		}
+
+		modTimes = append(modTimes, t)
+	}
+
+	return modTimes
+}
+
+func (r *tlsReloader) changed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, t := range r.stat() {
+		if !t.Equal(r.modTimes[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serverConfig returns the tls.Config to hand to the gRPC server.
+func (r *tlsReloader) serverConfig() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.config.Load(), nil
+		},
+	}
+}
+
+// watch reloads the files when SIGHUP arrives or, checked every interval,
+// when one of them has been modified. A half-rotated pair that fails to load
+// is retried on the next check.
+func (r *tlsReloader) watch(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+		}
+
+		if err := r.reload(); err != nil {
+			slog.Error("reloading tls certificates", "error", err)
+			continue
+		}
+
+		slog.Info("reloaded tls certificates")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Vyary/rdpc/proto"
+)
+
+// watchPollInterval bounds how long WatchQueries sleeps without a
+// notification, so leases that expire are still picked up.
+const watchPollInterval = 30 * time.Second
+
+// notifier wakes every waiter when notify is called.
+type notifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newNotifier() *notifier {
+	return &notifier{ch: make(chan struct{})}
+}
+
+// wait returns a channel that is closed on the next notify.
+func (n *notifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.ch
+}
+
+func (n *notifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
+
+// WatchQueries leases due queries to the worker and pushes them down the
+// stream, keeping at most capacity unexpired leases outstanding. It wakes when
+// the queue changes or the next query becomes due, and returns Unavailable
+// when the server shuts down.
+func (s *service) WatchQueries(wr *pb.WatchQueriesRequest, stream pb.Database_WatchQueriesServer) error {
+	if wr.WorkerId == "" {
+		return status.Error(codes.InvalidArgument, "worker_id is required")
+	}
+
+	capacity := int(wr.Capacity)
+	if capacity == 0 {
+		capacity = defaultLeaseBatch
+	}
+
+	ctx := stream.Context()
+
+	for {
+		changed := s.queue.wait()
+
+		outstanding, err := s.outstandingLeases(wr.WorkerId)
+		if err != nil {
+			return err
+		}
+
+		var delay time.Duration
+
+		if free := capacity - outstanding; free > 0 {
+			queries, err := s.leaseQueries(&pb.LeaseRequest{
+				WorkerId:     wr.WorkerId,
+				BatchSize:    uint32(free),
+				LeaseSeconds: wr.LeaseSeconds,
+			}, wr.RunOnce)
+			if err != nil {
+				return err
+			}
+
+			for _, q := range queries.Queries {
+				if err := stream.Send(q); err != nil {
+					return err
+				}
+			}
+
+			if len(queries.Queries) > 0 {
+				continue
+			}
+
+			delay, err = s.nextDue(wr.RunOnce)
+			if err != nil {
+				return err
+			}
+
+			if queries.RetryAfterMs > 0 {
+				delay = min(delay, time.Duration(queries.RetryAfterMs)*time.Millisecond)
+			}
+		} else {
+			// At capacity, due queries cannot be taken until the worker
+			// completes, fails or loses a lease, so only wait for that.
+			delay, err = s.leaseDeadline(wr.WorkerId)
+			if err != nil {
+				return err
+			}
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-s.shutdown:
+			timer.Stop()
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-changed:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *service) outstandingLeases(workerID string) (int, error) {
+	query := `
+	SELECT COUNT(*)
+	FROM queries
+	WHERE status = 'in_progress' AND lease_owner = ? AND lease_expires_at >= ?`
+
+	var n int
+
+	err := s.db.QueryRow(query, workerID, time.Now().UTC().Unix()).Scan(&n)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "counting leases for worker %s: %s", workerID, err.Error())
+	}
+
+	return n, nil
+}
+
+// leaseDeadline returns how long until the first of the worker's leases
+// expires, capped at watchPollInterval.
+func (s *service) leaseDeadline(workerID string) (time.Duration, error) {
+	query := `
+	SELECT MIN(lease_expires_at)
+	FROM queries
+	WHERE status = 'in_progress' AND lease_owner = ? AND lease_expires_at >= ?`
+
+	var expires *int64
+
+	err := s.db.QueryRow(query, workerID, time.Now().UTC().Unix()).Scan(&expires)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "finding lease deadline for worker %s: %s", workerID, err.Error())
+	}
+
+	if expires == nil {
+		return watchPollInterval, nil
+	}
+
+	// outstandingLeases still counts a lease in its final second.
+	delay := time.Until(time.Unix(*expires+1, 0))
+
+	return min(max(delay, time.Second), watchPollInterval), nil
+}
+
+// nextDue returns how long until a queued query becomes due or a lease
+// expires, capped at watchPollInterval.
+func (s *service) nextDue(runOnce bool) (time.Duration, error) {
+	query := `
+	SELECT MIN(due)
+	FROM (
+		SELECT MIN(next_run) AS due FROM queries WHERE status = 'queued' AND run_once = ?
+		UNION ALL
+		SELECT MIN(lease_expires_at) FROM queries WHERE status = 'in_progress' AND run_once = ?
+	)`
+
+	var due *int64
+
+	err := s.db.QueryRow(query, runOnce, runOnce).Scan(&due)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "finding next due query: %s", err.Error())
+	}
+
+	if due == nil {
+		return watchPollInterval, nil
+	}
+
+	// Leasing requires next_run to be strictly in the past.
+	delay := time.Until(time.Unix(*due+1, 0))
+
+	return min(max(delay, time.Second), watchPollInterval), nil
+}